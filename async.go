@@ -0,0 +1,145 @@
+package syslog
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// asyncBatchSize is the number of bytes after which the background
+// goroutine of an AsyncWriter writes out a batch without waiting for
+// the flush interval.
+const asyncBatchSize = 64 << 10
+
+var errAsyncClosed = errors.New("syslog: async writer closed")
+
+// AsyncWriter queues messages and writes them to an underlying
+// io.Writer on a background goroutine. See NewAsyncWriter.
+type AsyncWriter struct {
+	// dropped is accessed atomically and is the first field so it is
+	// 64-bit aligned on 32-bit platforms.
+	dropped uint64
+
+	out      io.Writer
+	queue    chan []byte
+	interval time.Duration
+	done     chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+	err    error
+}
+
+// NewAsyncWriter returns an io.WriteCloser that queues up to
+// queueSize messages and writes them to out on a background
+// goroutine. Queued messages are written in batches: a batch is
+// written when it grows beyond 64 KiB and every flushInterval. Every
+// write to out contains whole messages only, but a batch combines
+// messages in a single write, so out should be a stream such as a
+// file or a TCP connection.
+//
+// Write never blocks: when the queue is full the message is dropped,
+// Write returns ErrDropped and the Dropped counter is incremented.
+// The Writer and Logger of this package don't report ErrDropped as an
+// error.
+//
+// Close writes the queued messages, stops the background goroutine
+// and closes out if it implements io.Closer. It returns the first
+// error of writing to out.
+func NewAsyncWriter(out io.Writer, queueSize int, flushInterval time.Duration) (*AsyncWriter, error) {
+	if queueSize <= 0 {
+		return nil, errors.New("syslog: queue size of async writer must be positive")
+	}
+	if flushInterval <= 0 {
+		return nil, errors.New("syslog: flush interval of async writer must be positive")
+	}
+	w := &AsyncWriter{
+		out:      out,
+		queue:    make(chan []byte, queueSize),
+		interval: flushInterval,
+		done:     make(chan struct{}),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Write queues a copy of p.
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return 0, errAsyncClosed
+	}
+	select {
+	case w.queue <- append([]byte(nil), p...):
+		return len(p), nil
+	default:
+		atomic.AddUint64(&w.dropped, 1)
+		return 0, ErrDropped
+	}
+}
+
+// Dropped returns the number of messages dropped because the queue
+// was full.
+func (w *AsyncWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&w.dropped)
+}
+
+// Close writes the queued messages and stops the background
+// goroutine. Calling Close more than once is a no-op.
+func (w *AsyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.queue)
+	w.mu.Unlock()
+	<-w.done
+
+	err := w.err
+	if c, ok := w.out.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	var batch []byte
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if _, err := w.out.Write(batch); err != nil && w.err == nil {
+			w.err = err
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case msg, ok := <-w.queue:
+			if !ok {
+				flush()
+				return
+			}
+			if len(batch) > 0 && len(batch)+len(msg) > asyncBatchSize {
+				flush()
+			}
+			batch = append(batch, msg...)
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingWriter blocks every write until release is closed.
+type blockingWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *blockingWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func Test_async_writer_close_drains_queue(t *testing.T) {
+	out := &blockingWriter{release: make(chan struct{})}
+	close(out.release)
+	aw, err := syslog.NewAsyncWriter(out, 100, time.Hour)
+	if err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+
+	l := syslog.NewLogger(aw, syslog.USER, "hostname", "appName", "procid")
+	for i := 0; i < 10; i++ {
+		l.Log(log_level.INFO, "", nil, "message %d", i)
+	}
+	if err := aw.Close(); err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 10 || !strings.HasSuffix(lines[9], " message 9") {
+		t.Fatalf("got output: %q, but expected 10 messages in order", out.String())
+	}
+	if _, err := aw.Write([]byte("late")); err == nil {
+		t.Fatalf("expected an error when writing after Close")
+	}
+}
+
+func Test_async_writer_flushes_on_interval(t *testing.T) {
+	out := &blockingWriter{release: make(chan struct{})}
+	close(out.release)
+	aw, _ := syslog.NewAsyncWriter(out, 100, 10*time.Millisecond)
+	defer aw.Close()
+
+	aw.Write([]byte("message\n"))
+	deadline := time.Now().Add(time.Second)
+	for out.String() == "" {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the message to be flushed by the timer")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func Test_async_writer_drops_when_queue_is_full(t *testing.T) {
+	out := &blockingWriter{release: make(chan struct{})}
+	aw, _ := syslog.NewAsyncWriter(out, 2, time.Hour)
+
+	// a message larger than a batch makes the background goroutine
+	// write, and block, as soon as the next message arrives
+	aw.Write(bytes.Repeat([]byte("x"), 64<<10))
+
+	var dropped int
+	for i := 0; i < 10; i++ {
+		if _, err := aw.Write([]byte("message\n")); err == syslog.ErrDropped {
+			dropped++
+		}
+	}
+	if dropped == 0 || uint64(dropped) != aw.Dropped() {
+		t.Fatalf("got dropped: %v and Dropped(): %v, but expected equal non-zero values", dropped, aw.Dropped())
+	}
+
+	close(out.release)
+	aw.Close()
+}
+
+func Test_async_writer_invalid_arguments(t *testing.T) {
+	if _, err := syslog.NewAsyncWriter(&bytes.Buffer{}, 0, time.Second); err == nil {
+		t.Fatalf("expected an error for a queue size of 0")
+	}
+	if _, err := syslog.NewAsyncWriter(&bytes.Buffer{}, 1, 0); err == nil {
+		t.Fatalf("expected an error for a flush interval of 0")
+	}
+}
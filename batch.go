@@ -0,0 +1,105 @@
+package syslog
+
+import "time"
+
+// WithBatch makes a Writer created by Dial, DialTLS or DialLocal
+// collect the messages for a stream connection, such as tcp, and send
+// them in a single write when the batch holds maxMessages messages or
+// maxBytes bytes, or maxDelay after the first message of the batch was
+// added.
+// A limit of zero or less is not checked. A message is never split
+// across batches: a message that does not fit in the remaining space
+// causes the batch to be sent first, and a message larger than
+// maxBytes is sent on its own.
+//
+// Flush and Close of the Writer send the pending batch. An error of
+// sending a batch that was sent because of maxDelay is returned by
+// the next Write, Flush or Close. Datagram connections, such as udp,
+// send every message on its own. Other writers and the loggers report
+// an error when it is given.
+func WithBatch(maxMessages int, maxBytes int, maxDelay time.Duration) Option {
+	return func(o *options) {
+		o.batch = &batchLimits{maxMessages, maxBytes, maxDelay}
+	}
+}
+
+type batchLimits struct {
+	maxMessages int
+	maxBytes    int
+	maxDelay    time.Duration
+}
+
+// addToBatch adds the frame of p to the pending batch and sends the
+// batch when it is full. t.mu must be held.
+func (t *transport) addToBatch(p []byte) (int, error) {
+	if err := t.batchErr; err != nil {
+		t.batchErr = nil
+		return 0, err
+	}
+	frame := t.frame(p)
+	if t.batch.maxBytes > 0 && len(t.pending) > 0 && len(t.pending)+len(frame) > t.batch.maxBytes {
+		if err := t.flushBatch(); err != nil {
+			return 0, err
+		}
+	}
+	t.pending = append(t.pending, frame...)
+	t.count++
+
+	if t.batch.maxMessages > 0 && t.count >= t.batch.maxMessages ||
+		t.batch.maxBytes > 0 && len(t.pending) >= t.batch.maxBytes {
+		if err := t.flushBatch(); err != nil {
+			return 0, err
+		}
+	} else if t.timer == nil && t.batch.maxDelay > 0 {
+		t.timer = time.AfterFunc(t.batch.maxDelay, t.flushDelayed)
+	}
+	return len(p), nil
+}
+
+// flushBatch sends the pending batch. t.mu must be held.
+func (t *transport) flushBatch() error {
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	if err := t.batchErr; err != nil {
+		t.batchErr = nil
+		return err
+	}
+	if len(t.pending) == 0 {
+		return nil
+	}
+	err := t.send(t.pending)
+	t.pending = t.pending[:0]
+	t.count = 0
+	return err
+}
+
+func (t *transport) flushDelayed() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed || t.timer == nil {
+		return
+	}
+	t.timer = nil
+	if len(t.pending) > 0 {
+		err := t.send(t.pending)
+		t.pending = t.pending[:0]
+		t.count = 0
+		if err != nil {
+			t.batchErr = err
+		}
+	}
+}
+
+// Flush sends the pending batch.
+func (t *transport) Flush() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil
+	}
+	return t.flushBatch()
+}
@@ -0,0 +1,180 @@
+package syslog_test
+
+import (
+	"bufio"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// acceptOne accepts a single connection on a new tcp listener and
+// passes it to the returned channel.
+func acceptOne(t testing.TB) (net.Listener, chan net.Conn) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conns := make(chan net.Conn, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		conns <- conn
+	}()
+	return ln, conns
+}
+
+func Test_batch_max_messages(t *testing.T) {
+	ln, conns := acceptOne(t)
+	defer ln.Close()
+
+	w, err := syslog.Dial("tcp", ln.Addr().String(), syslog.USER|log_level.NOTICE, "laptop", "testapp", "123",
+		syslog.WithBatch(3, 0, time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	conn := <-conns
+	defer conn.Close()
+
+	w.Write([]byte("first"))
+	w.Write([]byte("second"))
+	conn.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	if n, _ := conn.Read(make([]byte, 1)); n != 0 {
+		t.Fatalf("got %d bytes, but expected the batch to be pending", n)
+	}
+
+	w.Write([]byte("third"))
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	r := bufio.NewReader(conn)
+	for _, expected := range []string{"first", "second", "third"} {
+		if msg := readOctetCounted(t, r); !strings.HasSuffix(msg, " "+expected) {
+			t.Fatalf("got message: %q, but expected: %q", msg, expected)
+		}
+	}
+}
+
+func Test_batch_max_bytes_never_splits_a_frame(t *testing.T) {
+	ln, conns := acceptOne(t)
+	defer ln.Close()
+
+	w, err := syslog.Dial("tcp", ln.Addr().String(), syslog.USER|log_level.NOTICE, "laptop", "testapp", "123",
+		syslog.WithBatch(0, 100, time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	conn := <-conns
+	defer conn.Close()
+
+	w.Write([]byte("first"))
+	w.Write([]byte(strings.Repeat("x", 20)))
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	r := bufio.NewReader(conn)
+	if msg := readOctetCounted(t, r); !strings.HasSuffix(msg, " first") {
+		t.Fatalf("got message: %q, but expected: %q", msg, "first")
+	}
+	conn.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	if _, err := r.ReadByte(); err == nil {
+		t.Fatalf("got a byte of the second message, but expected it to be pending")
+	}
+}
+
+func Test_batch_max_delay(t *testing.T) {
+	ln, conns := acceptOne(t)
+	defer ln.Close()
+
+	w, err := syslog.Dial("tcp", ln.Addr().String(), syslog.USER|log_level.NOTICE, "laptop", "testapp", "123",
+		syslog.WithBatch(100, 0, 10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	conn := <-conns
+	defer conn.Close()
+
+	w.Write([]byte("first"))
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	if msg := readOctetCounted(t, bufio.NewReader(conn)); !strings.HasSuffix(msg, " first") {
+		t.Fatalf("got message: %q, but expected: %q", msg, "first")
+	}
+}
+
+func Test_batch_close_flushes(t *testing.T) {
+	ln, conns := acceptOne(t)
+	defer ln.Close()
+
+	w, err := syslog.Dial("tcp", ln.Addr().String(), syslog.USER|log_level.NOTICE, "laptop", "testapp", "123",
+		syslog.WithBatch(100, 0, time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := syslog.NewAsyncWriter(w, 10, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := <-conns
+	defer conn.Close()
+
+	a.Write([]byte("first"))
+	if err := a.Close(); err != nil {
+		t.Fatalf("got error: %v, but expected: <nil>", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	data, _ := ioutil.ReadAll(conn)
+	if !strings.HasSuffix(string(data), " first") {
+		t.Fatalf("got: %q, but expected the batched message", data)
+	}
+}
+
+func Test_batch_is_rejected_by_other_writers(t *testing.T) {
+	w := syslog.NewWriter(ioutil.Discard, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123",
+		syslog.WithBatch(100, 0, time.Hour))
+	if _, err := w.Write([]byte("message")); err == nil {
+		t.Fatalf("expected an error for WithBatch on NewWriter")
+	}
+
+	l := syslog.NewLogger(ioutil.Discard, syslog.USER, "laptop", "testapp", "123",
+		syslog.WithBatch(100, 0, time.Hour))
+	if err := l.Log(log_level.ERROR, "", nil, "message"); err == nil {
+		t.Fatalf("expected an error for WithBatch on NewLogger")
+	}
+}
+
+func benchmarkDial(b *testing.B, opts ...syslog.Option) {
+	ln, conns := acceptOne(b)
+	defer ln.Close()
+
+	w, err := syslog.Dial("tcp", ln.Addr().String(), syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", opts...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	conn := <-conns
+	done := make(chan struct{})
+	go func() {
+		io.Copy(ioutil.Discard, conn)
+		close(done)
+	}()
+
+	msg := []byte("this is the message details")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Write(msg)
+	}
+	w.Close()
+	<-done
+}
+
+func Benchmark_dial_unbatched(b *testing.B) {
+	benchmarkDial(b)
+}
+
+func Benchmark_dial_batched(b *testing.B) {
+	benchmarkDial(b, syslog.WithBatch(256, 64*1024, 10*time.Millisecond))
+}
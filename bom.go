@@ -0,0 +1,19 @@
+package syslog
+
+// utf8BOM is the byte order mark that RFC 5424 requires at the start
+// of a MSG that is encoded in UTF-8.
+const utf8BOM = "\xef\xbb\xbf"
+
+// DefaultUTF8BOM is used by writers and loggers that are created
+// without WithUTF8BOM.
+const DefaultUTF8BOM = false
+
+// WithUTF8BOM sets whether the RFC 5424 formatting writes the UTF-8
+// byte order mark between the structured data and the message, so
+// receivers know the message is encoded in UTF-8. The BOM is only
+// written for messages that are valid UTF-8.
+func WithUTF8BOM(enabled bool) Option {
+	return func(o *options) {
+		o.utf8BOM = enabled
+	}
+}
@@ -0,0 +1,44 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+const bom = "\xef\xbb\xbf"
+
+func Test_utf8_bom(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithUTF8BOM(true))
+	w.Write([]byte("héllo"))
+
+	if !strings.HasSuffix(buf.String(), " - - "+bom+"héllo\n") {
+		t.Fatalf("got: %q, but expected the BOM before the message", buf.String())
+	}
+
+	m, err := syslog.Parse(buf.Bytes())
+	if err != nil || string(m.Msg) != "héllo" {
+		t.Fatalf("got message: %q and error: %v, but expected the message without BOM", m.Msg, err)
+	}
+}
+
+func Test_utf8_bom_omitted_for_invalid_utf8(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithUTF8BOM(true))
+	w.Write([]byte("h\xffllo"))
+
+	if strings.Contains(buf.String(), bom) {
+		t.Fatalf("got: %q, but expected no BOM for invalid UTF-8", buf.String())
+	}
+}
+
+func Test_utf8_bom_default(t *testing.T) {
+	buf := &bytes.Buffer{}
+	syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid").Log(log_level.INFO, "", nil, "message")
+	if strings.Contains(buf.String(), bom) {
+		t.Fatalf("got: %q, but expected no BOM by default", buf.String())
+	}
+}
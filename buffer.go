@@ -0,0 +1,58 @@
+package syslog
+
+import "io"
+
+// WithWriteBuffer makes a Writer collect messages in a buffer of
+// the given size and write them to the underlying io.Writer in
+// batches, instead of issuing a write for every message. The buffer
+// is written out when the next message does not fit in it, and on
+// Flush and Close.
+//
+// Every write to the underlying io.Writer contains whole messages
+// only: a message that does not fit in the remaining space causes
+// the buffer to be flushed first, and a message larger than the
+// buffer is written on its own. Readers of newline delimited output
+// do not depend on this, but datagram based transports do.
+//
+// The option only applies to writers created by NewWriter.
+func WithWriteBuffer(size int) Option {
+	return func(o *options) {
+		o.writeBuffer = size
+	}
+}
+
+func (w *Writer) writeBuffered(frame []byte) (int, error) {
+	if len(frame) > w.buf.Available() && w.buf.Buffered() > 0 {
+		if err := w.buf.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	return w.buf.Write(frame)
+}
+
+// Flush writes any buffered messages to the underlying io.Writer, and
+// sends the pending batch of a Writer created with WithBatch. It is a
+// no-op for writers without a write buffer or batch.
+func (w *Writer) Flush() error {
+	if w.buf != nil {
+		if err := w.buf.Flush(); err != nil {
+			return err
+		}
+	}
+	if t, ok := w.out.(*transport); ok {
+		return t.Flush()
+	}
+	return nil
+}
+
+// Close flushes any buffered messages and closes the underlying
+// io.Writer if it implements io.Closer.
+func (w *Writer) Close() error {
+	err := w.Flush()
+	if c, ok := w.out.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
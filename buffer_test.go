@@ -0,0 +1,87 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+// countingWriter counts the number of writes it receives, each of
+// which stands for a write syscall on a file or socket.
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.writes++
+	return c.Buffer.Write(p)
+}
+
+func Test_write_buffer(t *testing.T) {
+	out := &countingWriter{}
+	w := syslog.NewWriter(out, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithWriteBuffer(4096))
+
+	for i := 0; i < 10; i++ {
+		w.Write([]byte("message\n"))
+	}
+	if out.writes != 0 {
+		t.Fatalf("got %d writes before flush, but expected none", out.writes)
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if out.writes != 1 {
+		t.Fatalf("got %d writes, but expected 1", out.writes)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != 10 {
+		t.Fatalf("got %d messages, but expected 10", lines)
+	}
+}
+
+func Test_write_buffer_flushes_whole_messages(t *testing.T) {
+	out := &countingWriter{}
+	w := syslog.NewWriter(out, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithWriteBuffer(100))
+
+	for i := 0; i < 10; i++ {
+		w.Write([]byte("message\n"))
+	}
+	w.Close()
+
+	for _, line := range strings.SplitAfter(out.String(), "\n") {
+		if line != "" && !strings.HasPrefix(line, "<13>1 ") {
+			t.Fatalf("got split message: %q", line)
+		}
+	}
+	if out.writes < 2 {
+		t.Fatalf("got %d writes, but expected the buffer to be flushed when full", out.writes)
+	}
+}
+
+func benchmarkWriter(b *testing.B, opts ...syslog.Option) {
+	out := &countingWriter{}
+	w := syslog.NewWriter(out, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", opts...)
+	msg := []byte("Start HTTP server (addr=:8080)\n")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Write(msg)
+		if out.Len() > 1<<20 {
+			out.Reset()
+		}
+	}
+	w.Flush()
+	b.ReportMetric(float64(out.writes)/float64(b.N), "writes/op")
+}
+
+func Benchmark_writer_unbuffered(b *testing.B) {
+	benchmarkWriter(b)
+}
+
+func Benchmark_writer_buffered(b *testing.B) {
+	benchmarkWriter(b, syslog.WithWriteBuffer(64*1024))
+}
@@ -0,0 +1,48 @@
+package syslog
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// packagePrefix is the prefix of the names of the functions in this
+// package, which are left out of the captured call stack.
+const packagePrefix = "github.com/confetti-framework/syslog."
+
+// WithCallerStack captures up to depth frames of the call stack that
+// logged a message and stores them, innermost first, as the stack
+// param of the caller element. Frames are written as
+// package.Function(file.go:line) and separated by spaces.
+func WithCallerStack(depth int) Option {
+	return func(o *options) {
+		o.callerDepth = depth
+	}
+}
+
+func callerStack(depth int) string {
+	pcs := make([]uintptr, depth+16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []string
+	for len(stack) < depth {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) {
+			stack = append(stack, formatFrame(frame))
+		}
+		if !more {
+			break
+		}
+	}
+	return strings.Join(stack, " ")
+}
+
+func formatFrame(frame runtime.Frame) string {
+	function := frame.Function
+	if i := strings.LastIndexByte(function, '/'); i >= 0 {
+		function = function[i+1:]
+	}
+	return function + "(" + filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line) + ")"
+}
@@ -0,0 +1,24 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"regexp"
+	"testing"
+)
+
+func logFromHelper(l syslog.Logger) {
+	l.Log(log_level.ERROR, "", nil, "login failed")
+}
+
+func Test_caller_stack(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithCallerStack(2))
+	logFromHelper(l)
+
+	expected := regexp.MustCompile(`\[caller stack="syslog_test\.logFromHelper\(caller_test\.go:\d+\) syslog_test\.Test_caller_stack\(caller_test\.go:\d+\)"\]`)
+	if !expected.MatchString(buf.String()) {
+		t.Fatalf("non-expected caller stack: %s", buf.String())
+	}
+}
@@ -0,0 +1,70 @@
+package syslog
+
+import "sync"
+
+var capture struct {
+	mu   sync.Mutex
+	sink *TestSink
+}
+
+// EnableTestCapture makes every writer and logger that is created
+// from now on also write its messages to the returned TestSink, so
+// tests can assert on the messages logged by the code under test
+// without injecting a logger. Only messages that were written
+// successfully are recorded, for loggers with more than one
+// destination in the format of the first. Writers and loggers that
+// already exist are not affected. Call DisableTestCapture when the test is done.
+//
+// Capturing is meant for tests only: it is disabled unless
+// EnableTestCapture is called.
+func EnableTestCapture() *TestSink {
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+	capture.sink = &TestSink{}
+	return capture.sink
+}
+
+// DisableTestCapture stops capturing the messages of writers and
+// loggers that are created from now on.
+func DisableTestCapture() {
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+	capture.sink = nil
+}
+
+// captureSink returns the sink enabled by EnableTestCapture, or nil.
+func captureSink() *TestSink {
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+	return capture.sink
+}
+
+// TestSink records the messages written to it. It is safe for
+// concurrent use by multiple goroutines.
+type TestSink struct {
+	mu     sync.Mutex
+	frames []string
+}
+
+// Write records p as a single message.
+func (s *TestSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.frames = append(s.frames, string(p))
+	return len(p), nil
+}
+
+// Frames returns the recorded messages in the order in which they
+// were written.
+func (s *TestSink) Frames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.frames...)
+}
+
+// Reset removes the recorded messages.
+func (s *TestSink) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.frames = nil
+}
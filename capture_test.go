@@ -0,0 +1,41 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+func Test_test_capture(t *testing.T) {
+	before := syslog.NewLogger(&bytes.Buffer{}, syslog.USER, "hostname", "appName", "procid")
+
+	sink := syslog.EnableTestCapture()
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid")
+	w := syslog.NewWriter(&bytes.Buffer{}, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123")
+	syslog.DisableTestCapture()
+	after := syslog.NewLogger(&bytes.Buffer{}, syslog.USER, "hostname", "appName", "procid")
+
+	before.Log(log_level.INFO, "", nil, "before")
+	l.Log(log_level.ERROR, "LoginFailed", nil, "login failed")
+	w.Write([]byte("written"))
+	after.Log(log_level.INFO, "", nil, "after")
+
+	frames := sink.Frames()
+	if len(frames) != 2 {
+		t.Fatalf("got frames: %q, but expected 2", frames)
+	}
+	if frames[0] != buf.String() {
+		t.Fatalf("got frame: %q, but expected: %q", frames[0], buf.String())
+	}
+	if !strings.HasSuffix(frames[1], " laptop testapp 123 - - written\n") {
+		t.Fatalf("non-expected frame: %q", frames[1])
+	}
+
+	sink.Reset()
+	if len(sink.Frames()) != 0 {
+		t.Fatalf("expected no frames after Reset")
+	}
+}
@@ -0,0 +1,54 @@
+package syslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/confetti-framework/syslog/log_level"
+	"time"
+)
+
+// ceeCookie marks a MSG that holds a JSON object, as recognized by
+// rsyslog mmjsonparse and Graylog.
+const ceeCookie = "@cee: "
+
+// NewCEEFormatter returns a Formatter that writes the RFC 5424 header,
+// but puts the structured data and the message in a JSON object in
+// the MSG, prefixed by the CEE cookie. Every SD element becomes an
+// object keyed by its SD-ID, the message is stored under the msg key:
+//
+//	<11>1 2021-03-04T05:06:07.000+00:00 hostname appName procid LoginFailed - @cee: {"id1":{"par1":"val1"},"msg":"login failed"}
+func NewCEEFormatter() Formatter {
+	return ceeFormatter{}
+}
+
+type ceeFormatter struct{}
+
+func (ceeFormatter) Format(
+	pri log_level.Priority,
+	timestamp time.Time,
+	hostname string,
+	appName string,
+	procid string,
+	msgid string,
+	sd StructuredData,
+	msg []byte,
+) []byte {
+	payload := make(map[string]interface{}, len(sd)+1)
+	for _, id := range sd.Ids() {
+		elem := sd[id]
+		params := make(map[string]string, len(elem))
+		for _, name := range elem.Names() {
+			params[name] = elem[name]
+		}
+		payload[id] = params
+	}
+	payload["msg"] = string(bytes.TrimSuffix(msg, nl))
+
+	buf := bytes.NewBufferString(ceeCookie)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	// a map of strings can always be encoded
+	_ = enc.Encode(payload)
+
+	return formatSyslog(pri, timestamp, "", false, false, hostname, appName, procid, msgid, nil, sdOrder{}, buf.Bytes())
+}
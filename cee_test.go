@@ -0,0 +1,40 @@
+package syslog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_cee_formatter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithFormatter(syslog.NewCEEFormatter()))
+
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+	sd.Element("id2").Set("par2", `<a & "b">`)
+	l.Log(log_level.ERROR, "LoginFailed", sd, "login failed")
+
+	const prefix = " hostname appName procid LoginFailed - @cee: "
+	i := strings.Index(buf.String(), prefix)
+	if i < 0 || !strings.HasSuffix(buf.String(), "}\n") || strings.Count(buf.String(), "\n") != 1 {
+		t.Fatalf("non-expected output: %q", buf.String())
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(buf.String()[i+len(prefix):]), &payload); err != nil {
+		t.Fatalf("got error: %v, but expected a JSON object", err)
+	}
+	expected := map[string]interface{}{
+		"id1": map[string]interface{}{"par1": "val1"},
+		"id2": map[string]interface{}{"par2": `<a & "b">`},
+		"msg": "login failed",
+	}
+	if !reflect.DeepEqual(payload, expected) {
+		t.Fatalf("got payload: %v, but expected: %v", payload, expected)
+	}
+}
@@ -0,0 +1,46 @@
+package syslog
+
+import (
+	"io"
+	"reflect"
+)
+
+// flusher is implemented by writers that buffer messages, such as a
+// Writer with a write buffer and bufio.Writer.
+type flusher interface {
+	Flush() error
+}
+
+// Close flushes and closes the io.Writers of the logger, so messages
+// buffered by asynchronous or buffered writers reach their
+// destination before the process exits. Writers that implement
+// Flush() error are flushed and writers that implement io.Closer are
+// closed, other writers are left alone. Every writer is handled once,
+// also when several formatters write to it. Close returns the first
+// error.
+func (l *WriterLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var err error
+	done := make(map[io.Writer]bool, len(l.sinks))
+	for _, s := range l.sinks {
+		if s.w != nil && reflect.TypeOf(s.w).Comparable() {
+			if done[s.w] {
+				continue
+			}
+			done[s.w] = true
+		}
+		if f, ok := s.w.(flusher); ok {
+			if ferr := f.Flush(); err == nil {
+				err = ferr
+			}
+		}
+		if c, ok := s.w.(io.Closer); ok {
+			if cerr := c.Close(); err == nil {
+				err = cerr
+			}
+		}
+	}
+	return err
+}
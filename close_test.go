@@ -0,0 +1,72 @@
+package syslog_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+type closeWriter struct {
+	bytes.Buffer
+	closed int
+	err    error
+}
+
+func (w *closeWriter) Close() error {
+	w.closed++
+	return w.err
+}
+
+func Test_logger_close_flushes_and_closes(t *testing.T) {
+	out := &closeWriter{}
+	w := syslog.NewWriter(out, syslog.USER|log_level.INFO, "hostname", "appName", "procid", syslog.WithWriteBuffer(1024))
+	l := syslog.NewLogger(w, syslog.USER, "hostname", "appName", "procid")
+	l.Log(log_level.INFO, "", nil, "message")
+
+	if err := l.Close(); err != nil {
+		t.Fatalf("got error: %v, but expected: <nil>", err)
+	}
+	if !strings.Contains(out.String(), "message") {
+		t.Fatalf("got: %q, but expected the buffered message", out.String())
+	}
+	if out.closed != 1 {
+		t.Fatalf("got closed: %v, but expected: %v", out.closed, 1)
+	}
+}
+
+func Test_logger_close_flushes_bufio(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(bufio.NewWriter(buf), syslog.USER, "hostname", "appName", "procid")
+	l.Log(log_level.INFO, "", nil, "message")
+
+	if err := l.Close(); err != nil {
+		t.Fatalf("got error: %v, but expected: <nil>", err)
+	}
+	if !strings.Contains(buf.String(), "message") {
+		t.Fatalf("got: %q, but expected the flushed message", buf.String())
+	}
+}
+
+func Test_logger_close_without_closer(t *testing.T) {
+	l := syslog.NewLogger(&bytes.Buffer{}, syslog.USER, "hostname", "appName", "procid")
+	if err := l.Close(); err != nil {
+		t.Fatalf("got error: %v, but expected: <nil>", err)
+	}
+}
+
+func Test_logger_close_shared_writer_once(t *testing.T) {
+	out := &closeWriter{err: errors.New("close failed")}
+	l := syslog.NewTeeFormatterLogger(syslog.NewRFC5424Formatter(), out, syslog.NewCEEFormatter(), out,
+		syslog.USER, "hostname", "appName", "procid")
+
+	if err := l.Close(); err == nil || err.Error() != "close failed" {
+		t.Fatalf("got error: %v, but expected: close failed", err)
+	}
+	if out.closed != 1 {
+		t.Fatalf("got closed: %v, but expected: %v", out.closed, 1)
+	}
+}
@@ -0,0 +1,106 @@
+package syslog
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"time"
+)
+
+// Style is the appearance of the severity of a message on a terminal.
+type Style struct {
+	// Color is the ANSI escape sequence written before the severity,
+	// for example "\x1b[31m" for red. Empty for no color.
+	Color string
+	// Prefix is written before the severity, for example an emoji
+	// or a nerd font icon. Empty for no prefix.
+	Prefix string
+}
+
+// Theme maps severities to their Style. Severities that are not in
+// the theme are written without color and prefix.
+type Theme map[log_level.Priority]Style
+
+const ansiReset = "\x1b[0m"
+
+// DefaultTheme colors the severity and prefixes it with an emoji.
+var DefaultTheme = Theme{
+	log_level.EMERGENCY: {"\x1b[1;31m", "🚨"},
+	log_level.ALERT:     {"\x1b[1;31m", "🔔"},
+	log_level.CRITICAL:  {"\x1b[1;31m", "🔥"},
+	log_level.ERROR:     {"\x1b[31m", "❌"},
+	log_level.WARNING:   {"\x1b[33m", "⚠️"},
+	log_level.NOTICE:    {"\x1b[36m", "📣"},
+	log_level.INFO:      {"\x1b[32m", "ℹ️"},
+	log_level.DEBUG:     {"\x1b[90m", "🐛"},
+}
+
+// PlainTheme writes the severity without color and prefix, for
+// terminals that don't support them.
+var PlainTheme = Theme{}
+
+// NewConsoleFormatter returns a Formatter for humans reading the
+// messages on a terminal. The severity is styled by theme:
+//
+//	2006-01-02 15:04:05.000 ❌ ERR appName[procid]: MSGID MSG [SD]
+func NewConsoleFormatter(theme Theme) Formatter {
+	return consoleFormatter{theme}
+}
+
+// WithTheme formats messages with the formatter returned by
+// NewConsoleFormatter, using the given theme.
+func WithTheme(theme Theme) Option {
+	return WithFormatter(NewConsoleFormatter(theme))
+}
+
+type consoleFormatter struct {
+	theme Theme
+}
+
+func (f consoleFormatter) Format(
+	pri log_level.Priority,
+	timestamp time.Time,
+	_ string,
+	appName string,
+	procid string,
+	msgid string,
+	sd StructuredData,
+	msg []byte,
+) []byte {
+	buf := bytes.NewBuffer(make([]byte, 0, len(msg)+64))
+	buf.WriteString(timestamp.Format("2006-01-02 15:04:05.000"))
+	buf.WriteByte(' ')
+
+	style := f.theme[pri.Severity()]
+	buf.WriteString(style.Color)
+	if style.Prefix != "" {
+		buf.WriteString(style.Prefix)
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(strings.ToUpper(KeyBySeverity(pri.Severity())))
+	if style.Color != "" {
+		buf.WriteString(ansiReset)
+	}
+
+	if appName != "" {
+		buf.WriteByte(' ')
+		buf.WriteString(appName)
+		if procid != "" {
+			buf.WriteByte('[')
+			buf.WriteString(procid)
+			buf.WriteByte(']')
+		}
+	}
+	buf.WriteString(": ")
+	if msgid != "" {
+		buf.WriteString(msgid)
+		buf.WriteByte(' ')
+	}
+	buf.Write(bytes.TrimSuffix(msg, nl))
+	if len(sd) > 0 {
+		buf.WriteByte(' ')
+		buf.WriteString(sd.String())
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
@@ -0,0 +1,53 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_console_theme(t *testing.T) {
+	timestamp := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	theme := syslog.Theme{log_level.ERROR: {Color: "\x1b[31m", Prefix: "[!]"}}
+
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithTheme(theme),
+		syslog.WithClock(func() time.Time { return timestamp }),
+	)
+	l.Log(log_level.ERROR, "LoginFailed", nil, "login failed")
+	l.Log(log_level.INFO, "", nil, "logged in")
+
+	expected := "2021-03-04 05:06:07.000 \x1b[31m[!] ERR\x1b[0m appName[procid]: LoginFailed login failed\n" +
+		"2021-03-04 05:06:07.000 INFO appName[procid]: logged in\n"
+	if buf.String() != expected {
+		t.Fatalf("got: %q, but expected: %q", buf.String(), expected)
+	}
+}
+
+func Test_console_plain_theme(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithTheme(syslog.PlainTheme))
+	l.Log(log_level.ERROR, "", nil, "login failed")
+
+	if strings.Contains(buf.String(), "\x1b[") || strings.Contains(buf.String(), syslog.DefaultTheme[log_level.ERROR].Prefix) {
+		t.Fatalf("got: %q, but expected no color and no prefix", buf.String())
+	}
+	if !strings.HasSuffix(buf.String(), " ERR appName[procid]: login failed\n") {
+		t.Fatalf("non-expected output: %q", buf.String())
+	}
+}
+
+func Test_console_default_theme(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithTheme(syslog.DefaultTheme))
+	l.Log(log_level.WARNING, "", nil, "disk almost full")
+
+	style := syslog.DefaultTheme[log_level.WARNING]
+	if !strings.Contains(buf.String(), style.Color+style.Prefix+" WARNING") {
+		t.Fatalf("got: %q, but expected the warning style", buf.String())
+	}
+}
@@ -0,0 +1,34 @@
+package syslog
+
+import (
+	"context"
+	"github.com/confetti-framework/syslog/log_level"
+)
+
+// WithContextExtractor adds a function that derives structured data,
+// such as a trace id, from the context passed to LogContext. When
+// several extractors are configured, they are called in order. On a
+// conflicting param the structured data of the call wins over that of
+// the extractors, and an earlier extractor wins over a later one.
+func WithContextExtractor(extractor func(context.Context) StructuredData) Option {
+	return func(o *options) {
+		o.extractors = append(o.extractors, extractor)
+	}
+}
+
+// LogContext generates a syslog message like Log, with the structured
+// data extracted from ctx by the extractors of WithContextExtractor
+// merged into sd. sd itself is never modified.
+func (l *WriterLogger) LogContext(ctx context.Context, severity log_level.Priority, msgId string, sd StructuredData, msgFormat string, a ...interface{}) error {
+	l.mu.Lock()
+	extractors := l.opts.extractors
+	l.mu.Unlock()
+
+	if len(extractors) > 0 && l.enabled(severity) {
+		sd = sd.Clone()
+		for _, extract := range extractors {
+			merge(sd, extract(ctx))
+		}
+	}
+	return l.Log(severity, msgId, sd, msgFormat, a...)
+}
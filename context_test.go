@@ -0,0 +1,71 @@
+package syslog_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+type contextKey string
+
+func traceExtractor(ctx context.Context) syslog.StructuredData {
+	sd := syslog.StructuredData{}
+	if traceID, ok := ctx.Value(contextKey("trace")).(string); ok {
+		sd.Element("trace").Set("id", traceID)
+	}
+	if userID, ok := ctx.Value(contextKey("user")).(string); ok {
+		sd.Element("trace").Set("user", userID)
+	}
+	return sd
+}
+
+func Test_log_context(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithContextExtractor(traceExtractor))
+
+	ctx := context.WithValue(context.Background(), contextKey("trace"), "abc")
+	ctx = context.WithValue(ctx, contextKey("user"), "42")
+	l.LogContext(ctx, log_level.INFO, "", nil, "message")
+
+	if !strings.Contains(buf.String(), `[trace id="abc" user="42"] message`) {
+		t.Fatalf("got: %q, but expected the structured data of the context", buf.String())
+	}
+}
+
+func Test_log_context_call_site_wins(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithContextExtractor(traceExtractor))
+
+	ctx := context.WithValue(context.Background(), contextKey("trace"), "abc")
+	ctx = context.WithValue(ctx, contextKey("user"), "42")
+	sd := syslog.StructuredData{}
+	sd.Element("trace").Set("id", "xyz")
+	l.LogContext(ctx, log_level.INFO, "", sd, "message")
+
+	if !strings.Contains(buf.String(), `[trace id="xyz" user="42"] message`) {
+		t.Fatalf("got: %q, but expected the call site to win", buf.String())
+	}
+	if sd.Element("trace").Get("user") != "" {
+		t.Fatalf("got: %v, but expected the structured data of the call to be unchanged", sd)
+	}
+}
+
+func Test_log_context_first_extractor_wins(t *testing.T) {
+	buf := &bytes.Buffer{}
+	other := func(ctx context.Context) syslog.StructuredData {
+		sd := syslog.StructuredData{}
+		sd.Element("trace").Set("id", "other").Set("span", "1")
+		return sd
+	}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithContextExtractor(traceExtractor), syslog.WithContextExtractor(other))
+
+	l.LogContext(context.WithValue(context.Background(), contextKey("trace"), "abc"), log_level.INFO, "", nil, "message")
+
+	if !strings.Contains(buf.String(), `[trace id="abc" span="1"] message`) {
+		t.Fatalf("got: %q, but expected the first extractor to win", buf.String())
+	}
+}
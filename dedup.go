@@ -0,0 +1,73 @@
+package syslog
+
+import (
+	"bytes"
+	"hash/fnv"
+	"io"
+	"sync"
+	"time"
+)
+
+// NewWindowedDedupWriter returns an io.Writer that forwards a message
+// to out only if the same message was not forwarded within the last
+// window. Unlike suppressing consecutive repeats, this also catches
+// messages that are repeated with other messages in between.
+//
+// Messages are compared by a hash of their content; the timestamp of
+// RFC 5424 formatted messages is left out of the comparison.
+// The returned io.Writer is safe for concurrent use by multiple
+// goroutines.
+func NewWindowedDedupWriter(out io.Writer, window time.Duration) io.Writer {
+	return &dedupWriter{
+		out:    out,
+		window: window,
+		seen:   map[uint64]time.Time{},
+	}
+}
+
+type dedupWriter struct {
+	mu        sync.Mutex
+	out       io.Writer
+	window    time.Duration
+	seen      map[uint64]time.Time
+	lastClean time.Time
+}
+
+func (w *dedupWriter) Write(d []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(w.lastClean) >= w.window {
+		for hash, seen := range w.seen {
+			if now.Sub(seen) >= w.window {
+				delete(w.seen, hash)
+			}
+		}
+		w.lastClean = now
+	}
+
+	hash := contentHash(d)
+	if seen, ok := w.seen[hash]; ok && now.Sub(seen) < w.window {
+		return len(d), nil
+	}
+	w.seen[hash] = now
+	return w.out.Write(d)
+}
+
+// contentHash hashes a message, leaving out the timestamp if the
+// message is formatted as defined in RFC 5424.
+func contentHash(d []byte) uint64 {
+	h := fnv.New64a()
+	if len(d) > 0 && d[0] == '<' {
+		if i := bytes.IndexByte(d, ' '); i > 0 {
+			if j := bytes.IndexByte(d[i+1:], ' '); j >= 0 {
+				h.Write(d[:i])
+				h.Write(d[i+1+j:])
+				return h.Sum64()
+			}
+		}
+	}
+	h.Write(d)
+	return h.Sum64()
+}
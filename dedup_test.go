@@ -0,0 +1,35 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_windowed_dedup_writer(t *testing.T) {
+	buf := &bytes.Buffer{}
+	dedup := syslog.NewWindowedDedupWriter(buf, 100*time.Millisecond)
+	l := syslog.NewLogger(dedup, syslog.USER, "hostname", "appName", "procid")
+
+	for i := 0; i < 3; i++ {
+		l.Log(log_level.ERROR, "", nil, "connection refused")
+		l.Log(log_level.ERROR, "", nil, "disk full")
+	}
+	if n := strings.Count(buf.String(), "\n"); n != 2 {
+		t.Fatalf("got %d messages, but expected 2: %s", n, buf.String())
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	l.Log(log_level.ERROR, "", nil, "connection refused")
+	l.Log(log_level.ERROR, "", nil, "disk full")
+
+	if n := strings.Count(buf.String(), "connection refused"); n != 2 {
+		t.Fatalf("got %d connection refused messages, but expected 2: %s", n, buf.String())
+	}
+	if n := strings.Count(buf.String(), "disk full"); n != 2 {
+		t.Fatalf("got %d disk full messages, but expected 2: %s", n, buf.String())
+	}
+}
@@ -0,0 +1,42 @@
+package syslog
+
+// WithDefaultStructuredData adds sd to every message, for example the
+// origin element that identifies the application. On a conflicting
+// param the structured data of the message wins. sd is copied, later
+// changes to it are ignored.
+func WithDefaultStructuredData(sd StructuredData) Option {
+	return func(o *options) {
+		o.defaultSD = sd.Clone()
+	}
+}
+
+// Maximum lengths of the params of the origin element, as defined in
+// RFC 5424.
+const (
+	maxOriginSoftwareLength  = 48
+	maxOriginSwVersionLength = 32
+)
+
+// OriginElement returns the origin element defined in RFC 5424, which
+// identifies the originator of a message:
+//
+//	sd := syslog.StructuredData{"origin": syslog.OriginElement("10.0.0.1", "32473", "shop", "1.2.0")}
+//
+// Empty arguments are left out. The software and version are truncated
+// to the 48 and 32 characters allowed by RFC 5424.
+func OriginElement(ip, enterpriseID, software, version string) SDElement {
+	e := SDElement{}
+	if ip != "" {
+		e.Set("ip", ip)
+	}
+	if enterpriseID != "" {
+		e.Set("enterpriseId", enterpriseID)
+	}
+	if software != "" {
+		e.Set("software", truncate(software, maxOriginSoftwareLength))
+	}
+	if version != "" {
+		e.Set("swVersion", truncate(version, maxOriginSwVersionLength))
+	}
+	return e
+}
@@ -0,0 +1,43 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+func Test_default_structured_data(t *testing.T) {
+	defaults := syslog.StructuredData{"origin": syslog.OriginElement("10.0.0.1", "32473", "shop", "1.2.0")}
+	defaults.Element("env@32473").Set("name", "production").Set("region", "eu")
+
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithDefaultStructuredData(defaults))
+	defaults.Element("env@32473").Set("name", "changed")
+
+	sd := syslog.StructuredData{}
+	sd.Element("env@32473").Set("region", "us")
+	l.Log(log_level.INFO, "", sd, "message")
+
+	expected := `[env@32473 name="production" region="us"]` +
+		`[origin enterpriseId="32473" ip="10.0.0.1" software="shop" swVersion="1.2.0"] message`
+	if !strings.HasSuffix(strings.TrimSpace(buf.String()), expected) {
+		t.Fatalf("got: %q, but expected suffix: %q", buf.String(), expected)
+	}
+	if sd.Element("env@32473").Get("name") != "" {
+		t.Fatalf("got: %v, but expected the structured data of the call to be unchanged", sd)
+	}
+}
+
+func Test_origin_element(t *testing.T) {
+	sd := syslog.StructuredData{"origin": syslog.OriginElement("", "", strings.Repeat("s", 50), "1.0")}
+
+	expected := `[origin software="` + strings.Repeat("s", 48) + `" swVersion="1.0"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+	if err := sd.Validate(); err != nil {
+		t.Fatalf("got error: %v, but expected: <nil>", err)
+	}
+}
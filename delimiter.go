@@ -0,0 +1,30 @@
+package syslog
+
+import "bytes"
+
+// WithNULDelimiter terminates every message with a NUL byte instead
+// of a newline, for receivers that delimit records by NUL. NUL bytes
+// inside the message are replaced by a space, so the only NUL in a
+// frame is its delimiter.
+func WithNULDelimiter() Option {
+	return func(o *options) {
+		o.nulDelimiter = true
+	}
+}
+
+// delimit replaces the trailing newline of a formatted frame by the
+// delimiter set by WithNULDelimiter. It never modifies frame.
+func (o *options) delimit(frame []byte) []byte {
+	if !o.nulDelimiter {
+		return frame
+	}
+	frame = bytes.TrimSuffix(frame, nl)
+	out := make([]byte, len(frame)+1)
+	for i, c := range frame {
+		if c == 0 {
+			c = ' '
+		}
+		out[i] = c
+	}
+	return out
+}
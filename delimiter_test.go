@@ -0,0 +1,35 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+func Test_nul_delimiter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithNULDelimiter())
+	w.Write([]byte("with\x00nul\n"))
+
+	frame := buf.String()
+	if !strings.HasSuffix(frame, " - - with nul\x00") {
+		t.Fatalf("got frame: %q, but expected it to end in a NUL byte", frame)
+	}
+	if strings.Count(frame, "\x00") != 1 || strings.Contains(frame, "\n") {
+		t.Fatalf("got frame: %q, but expected only the delimiting NUL byte", frame)
+	}
+}
+
+func Test_nul_delimiter_logger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithNULDelimiter())
+	l.Log(log_level.INFO, "", nil, "first")
+	l.Log(log_level.INFO, "", nil, "second")
+
+	frames := strings.Split(buf.String(), "\x00")
+	if len(frames) != 3 || frames[2] != "" || !strings.HasSuffix(frames[0], " first") || !strings.HasSuffix(frames[1], " second") {
+		t.Fatalf("got frames: %q, but expected 2 NUL delimited frames", frames)
+	}
+}
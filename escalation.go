@@ -0,0 +1,78 @@
+package syslog
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithEscalation raises the severity of a message by one level, for
+// example from WARNING to ERROR, when a message with the same msgId
+// and text is logged at least after times within a fixed window of
+// interval. An escalated message explains why in the escalation
+// element:
+//
+//	[escalation original="WARNING" reason="repeated 5 times"]
+//
+// Escalation is applied by loggers, before WithSeverityClamp.
+func WithEscalation(after int, interval time.Duration) Option {
+	return func(o *options) {
+		o.escalation = &escalation{
+			after:    after,
+			interval: interval,
+			counts:   map[string]int{},
+		}
+	}
+}
+
+// escalation counts the repetitions of messages in fixed windows of
+// interval.
+type escalation struct {
+	mu          sync.Mutex
+	after       int
+	interval    time.Duration
+	windowStart time.Time
+	counts      map[string]int
+}
+
+// repeated returns how often the message was logged in the current
+// window, including this time.
+func (e *escalation) repeated(msgId string, msg []byte, now time.Time) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if now.Sub(e.windowStart) >= e.interval {
+		e.windowStart = now
+		e.counts = map[string]int{}
+	}
+	key := msgId + "\x00" + string(msg)
+	e.counts[key]++
+	return e.counts[key]
+}
+
+// escalate returns the severity of the message, raised by one level
+// when it is repeated too often, and sd with the escalation element
+// in that case. sd itself is never modified.
+func (o *options) escalate(severity log_level.Priority, msgId string, msg []byte, now time.Time, sd StructuredData) (log_level.Priority, StructuredData) {
+	if o.escalation == nil || severity == log_level.EMERGENCY {
+		return severity, sd
+	}
+	n := o.escalation.repeated(msgId, msg, now)
+	if n < o.escalation.after {
+		return severity, sd
+	}
+	sd = sd.Clone()
+	sd.Element("escalation").
+		Set("reason", "repeated "+strconv.Itoa(n)+" times").
+		Set("original", severityName(severity))
+	return severity - 1, sd
+}
+
+// severityName returns the name of the severity of p, such as
+// "WARNING".
+func severityName(p log_level.Priority) string {
+	s := p.Severity().String()
+	return s[strings.IndexByte(s, '|')+1:]
+}
@@ -0,0 +1,51 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_escalation(t *testing.T) {
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithEscalation(3, time.Minute), syslog.WithClock(func() time.Time { return now }))
+
+	for i := 0; i < 3; i++ {
+		l.Log(log_level.WARNING, "DiskFull", nil, "disk full")
+	}
+	l.Log(log_level.WARNING, "DiskFull", nil, "other message")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	for i, prefix := range []string{"<12>1", "<12>1", "<11>1", "<12>1"} {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Fatalf("got line %d: %s, but expected prefix: %s", i, lines[i], prefix)
+		}
+	}
+	expected := ` DiskFull [escalation original="WARNING" reason="repeated 3 times"] disk full`
+	if !strings.HasSuffix(lines[2], expected) {
+		t.Fatalf("got: %s, but expected suffix: %s", lines[2], expected)
+	}
+	if strings.Contains(lines[3], "escalation") {
+		t.Fatalf("got: %s, but expected no escalation", lines[3])
+	}
+}
+
+func Test_escalation_window(t *testing.T) {
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithEscalation(2, time.Minute), syslog.WithClock(func() time.Time { return now }))
+
+	l.Log(log_level.WARNING, "", nil, "disk full")
+	now = now.Add(time.Minute)
+	l.Log(log_level.WARNING, "", nil, "disk full")
+
+	if strings.Contains(buf.String(), "escalation") {
+		t.Fatalf("got: %s, but expected no escalation", buf.String())
+	}
+}
@@ -0,0 +1,30 @@
+package syslog
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// writeParamValue writes an SD-PARAM value, escaping '"', '\' and ']'
+// as defined in RFC 5424. RFC 5424 defines no other escapes, so to
+// keep the message on a single line control characters, such as
+// newline and tab, are replaced by a space, and to keep it valid UTF-8
+// invalid UTF-8 is replaced by the Unicode replacement character.
+// Parse returns the value as written.
+func writeParamValue(buf *bytes.Buffer, value string) {
+	for i := 0; i < len(value); {
+		r, size := utf8.DecodeRuneInString(value[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			buf.WriteRune(utf8.RuneError)
+		case r == '"' || r == '\\' || r == ']':
+			buf.WriteByte('\\')
+			buf.WriteByte(byte(r))
+		case r < 0x20 || r == 0x7f:
+			buf.WriteByte(' ')
+		default:
+			buf.WriteString(value[i : i+size])
+		}
+		i += size
+	}
+}
@@ -0,0 +1,73 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func Test_replace_control_characters_in_param_values(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("id1").
+		Set("lines", "line1\nline2").
+		Set("nul", "a\x00b").
+		Set("tab", "a\tb").
+		Set("quoted", `"a\b]`)
+
+	expected := `[id1 lines="line1 line2" nul="a b" quoted="\"a\\b\]" tab="a b"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_replace_invalid_utf8_in_param_values(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "a\x80b€")
+
+	expected := `[id1 par1="a` + "�" + `b€"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_escaped_param_values_keep_message_single_line(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid")
+
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("lines", "line1\nline2").Set("byte", "\x80")
+	l.Log(log_level.INFO, "", sd, "message")
+
+	if strings.Count(buf.String(), "\n") != 1 || !utf8.Valid(buf.Bytes()) {
+		t.Fatalf("got: %q, but expected a single line of valid UTF-8", buf.String())
+	}
+	if _, err := syslog.Parse(buf.Bytes()); err != nil {
+		t.Fatalf("got error: %v, but expected the message to parse", err)
+	}
+}
+
+func Test_param_values_round_trip_through_parse(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("id1").
+		Set("lines", "line1\r\nline2").
+		Set("literal", `line1\nline2`).
+		Set("quoted", `"a\b]`)
+
+	frame := syslog.Format(syslog.USER|log_level.INFO, time.Now(), "hostname", "appName", "procid", "", sd, []byte("message"))
+	m, err := syslog.Parse(frame)
+	if err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+
+	elem := m.StructuredData.Element("id1")
+	expected := map[string]string{"lines": "line1  line2", "literal": `line1\nline2`, "quoted": `"a\b]`}
+	for name, value := range expected {
+		if elem.Get(name) != value {
+			t.Fatalf("got %s: %q, but expected: %q", name, elem.Get(name), value)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package syslog
+
+import "github.com/confetti-framework/syslog/log_level"
+
+// DialLocalPaths exposes dialLocal, so tests can use sockets in a
+// temporary directory.
+func DialLocalPaths(paths []string, pri log_level.Priority, hostname, appName, procid string, opts ...Option) (*Writer, error) {
+	return dialLocal(paths, pri, hostname, appName, procid, opts)
+}
+
+// NewJournaldWriterPath exposes newJournaldWriter, so tests can use a
+// socket in a temporary directory.
+func NewJournaldWriterPath(path string) (*JournaldWriter, error) {
+	return newJournaldWriter(path)
+}
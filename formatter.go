@@ -0,0 +1,187 @@
+package syslog
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog/log_level"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Formatter turns the fields of a syslog message into the bytes that
+// are written.
+type Formatter interface {
+	Format(
+		pri log_level.Priority,
+		timestamp time.Time,
+		hostname string,
+		appName string,
+		procid string,
+		msgid string,
+		sd StructuredData,
+		msg []byte,
+	) []byte
+}
+
+// WithFormatter replaces the RFC 5424 formatting of messages.
+func WithFormatter(f Formatter) Option {
+	return func(o *options) {
+		o.formatter = f
+	}
+}
+
+// FormatterFunc adapts an ordinary function to a Formatter.
+type FormatterFunc func(
+	pri log_level.Priority,
+	timestamp time.Time,
+	hostname string,
+	appName string,
+	procid string,
+	msgid string,
+	sd StructuredData,
+	msg []byte,
+) []byte
+
+// Format calls f.
+func (f FormatterFunc) Format(
+	pri log_level.Priority,
+	timestamp time.Time,
+	hostname string,
+	appName string,
+	procid string,
+	msgid string,
+	sd StructuredData,
+	msg []byte,
+) []byte {
+	return f(pri, timestamp, hostname, appName, procid, msgid, sd, msg)
+}
+
+// Format returns a message formatted as defined in RFC 5424, as a
+// Writer or Logger without options would write it, without writing
+// it. Use it to hand messages to a transport that is not an
+// io.Writer, such as the client of a message queue, or to sign them.
+func Format(
+	pri log_level.Priority,
+	timestamp time.Time,
+	hostname string,
+	appName string,
+	procid string,
+	msgid string,
+	sd StructuredData,
+	msg []byte,
+) []byte {
+	return formatSyslog(pri, timestamp, "", false, DefaultUTF8BOM, hostname, appName, procid, msgid, sd, sdOrder{}, msg)
+}
+
+// NewRFC5424Formatter returns the Formatter that is used when no
+// other Formatter is set, which formats messages as defined in
+// RFC 5424. It is useful to decorate the default formatting, for
+// example to change the newline policy of a collector.
+func NewRFC5424Formatter() Formatter {
+	return newOptions(nil).formatter
+}
+
+// rfc5424Formatter formats messages as defined in RFC 5424.
+type rfc5424Formatter struct {
+	compact    bool
+	bom        bool
+	timeFormat string
+	order      sdOrder
+}
+
+func (f rfc5424Formatter) Format(
+	pri log_level.Priority,
+	timestamp time.Time,
+	hostname string,
+	appName string,
+	procid string,
+	msgid string,
+	sd StructuredData,
+	msg []byte,
+) []byte {
+	return formatSyslog(pri, timestamp, f.timeFormat, f.compact, f.bom, hostname, appName, procid, msgid, sd, f.order, msg)
+}
+
+func (f rfc5424Formatter) formatTo(
+	buf *bytes.Buffer,
+	pri log_level.Priority,
+	timestamp time.Time,
+	hostname string,
+	appName string,
+	procid string,
+	msgid string,
+	sd StructuredData,
+	msg []byte,
+) {
+	writeSyslog(buf, pri, timestamp, f.timeFormat, f.compact, f.bom, hostname, appName, procid, msgid, sd, f.order, msg)
+}
+
+// NewMinimalFormatter returns a Formatter for constrained devices that
+// only writes the PRI, version, timestamp and message. All other
+// header fields, including the structured data, are written as the
+// NILVALUE without being looked at:
+//
+//	<PRI>1 TIMESTAMP - - - - - MSG
+func NewMinimalFormatter() Formatter {
+	return minimalFormatter{}
+}
+
+type minimalFormatter struct{}
+
+func (minimalFormatter) Format(
+	pri log_level.Priority,
+	timestamp time.Time,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ StructuredData,
+	msg []byte,
+) []byte {
+	buf := bytes.NewBuffer(make([]byte, 0, len(msg)+48))
+	buf.WriteByte('<')
+	buf.WriteString(strconv.Itoa(int(pri)))
+	buf.WriteString(">1 ")
+	buf.WriteString(timestamp.Format(rfc3339Milli))
+	buf.WriteString(" - - - - - ")
+	buf.Write(msg)
+	if len(msg) == 0 || msg[len(msg)-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// NewPriValueWriter returns a Writer for collectors that expect only
+// the numeric priority followed by a space and the message, such as
+// some Fluentd inputs. With pri USER|NOTICE a write of "message"
+// produces:
+//
+//	13 message
+//
+// The structured data and all other header fields are left out. A
+// Formatter set by WithFormatter is ignored.
+func NewPriValueWriter(out io.Writer, pri log_level.Priority, opts ...Option) *Writer {
+	return NewWriter(out, pri, "", "", "", append(opts, WithFormatter(priValueFormatter{}))...)
+}
+
+type priValueFormatter struct{}
+
+func (priValueFormatter) Format(
+	pri log_level.Priority,
+	_ time.Time,
+	_ string,
+	_ string,
+	_ string,
+	_ string,
+	_ StructuredData,
+	msg []byte,
+) []byte {
+	buf := bytes.NewBuffer(make([]byte, 0, len(msg)+8))
+	buf.WriteString(strconv.Itoa(int(pri)))
+	buf.WriteByte(' ')
+	buf.Write(msg)
+	if len(msg) == 0 || msg[len(msg)-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
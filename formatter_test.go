@@ -0,0 +1,101 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func Test_minimal_formatter(t *testing.T) {
+	ts := time.Date(2017, 8, 15, 23, 13, 15, 335e6, time.UTC)
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+
+	got := string(syslog.NewMinimalFormatter().Format(
+		syslog.USER|log_level.NOTICE, ts, "hostname", "appName", "procid", "msgid", sd, []byte("message")))
+
+	expected := "<13>1 2017-08-15T23:13:15.335+00:00 - - - - - message\n"
+	if got != expected {
+		t.Fatalf("got: %q, but expected: %q", got, expected)
+	}
+}
+
+func Test_minimal_formatter_with_logger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithFormatter(syslog.NewMinimalFormatter()))
+
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+	l.Log(log_level.ERROR, "LoginFailed", sd, "login failed")
+
+	expected := regexp.MustCompile(`^<11>1 \S+ - - - - - login failed\n$`)
+	if !expected.MatchString(buf.String()) {
+		t.Fatalf("non-expected output: %s", buf.String())
+	}
+}
+
+func Test_formatter_func_decorating_rfc5424(t *testing.T) {
+	rfc5424 := syslog.NewRFC5424Formatter()
+	withoutNewline := syslog.FormatterFunc(func(
+		pri log_level.Priority,
+		timestamp time.Time,
+		hostname, appName, procid, msgid string,
+		sd syslog.StructuredData,
+		msg []byte,
+	) []byte {
+		return bytes.TrimSuffix(rfc5424.Format(pri, timestamp, hostname, appName, procid, msgid, sd, msg), []byte("\n"))
+	})
+
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithFormatter(withoutNewline))
+	l.Log(log_level.ERROR, "LoginFailed", nil, "login failed")
+
+	expected := regexp.MustCompile(`^<11>1 \S+ hostname appName procid LoginFailed - login failed$`)
+	if !expected.MatchString(buf.String()) {
+		t.Fatalf("non-expected output: %q", buf.String())
+	}
+}
+
+func Test_pri_value_writer(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewPriValueWriter(buf, syslog.USER|log_level.NOTICE)
+	w.Write([]byte("message"))
+
+	expected := "13 message\n"
+	if buf.String() != expected {
+		t.Fatalf("got: %q, but expected: %q", buf.String(), expected)
+	}
+}
+
+func Test_format(t *testing.T) {
+	ts := time.Date(2017, 8, 15, 23, 13, 15, 335e6, time.UTC)
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+
+	got := string(syslog.Format(syslog.USER|log_level.NOTICE, ts, "hostname", "appName", "procid", "msgid", sd, []byte("message")))
+
+	expected := `<13>1 2017-08-15T23:13:15.335+00:00 hostname appName procid msgid [id1 par1="val1"] message` + "\n"
+	if got != expected {
+		t.Fatalf("got: %q, but expected: %q", got, expected)
+	}
+}
+
+func Test_rfc5424_formatter_matches_default(t *testing.T) {
+	timestamp := time.Date(2021, 3, 4, 5, 6, 7, 8000, time.UTC)
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithClock(func() time.Time { return timestamp }))
+
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+	l.Log(log_level.ERROR, "LoginFailed", sd, "login failed")
+
+	formatted := syslog.NewRFC5424Formatter().Format(syslog.USER|log_level.ERROR, timestamp, "hostname", "appName", "procid", "LoginFailed", sd, []byte("login failed"))
+	if string(formatted) != buf.String() {
+		t.Fatalf("got: %q, but expected the output of the default logger: %q", formatted, buf.String())
+	}
+}
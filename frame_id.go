@@ -0,0 +1,50 @@
+package syslog
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// metaElement is the SD-ID of the meta element reserved by RFC 5424.
+const metaElement = "meta"
+
+// WithFrameID gives every message a unique id that sorts by the time
+// the message was created. The id is a ULID stored as the frameId
+// param of the meta element. Use WithIDGenerator to generate other
+// kinds of ids.
+func WithFrameID() Option {
+	return func(o *options) {
+		o.frameID = true
+	}
+}
+
+func (o *options) frameIDFor(timestamp time.Time) string {
+	if o.idGenerator != nil {
+		return o.idGenerator.NextID()
+	}
+	return newULID(timestamp)
+}
+
+// crockford is the base32 alphabet used to encode ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID returns a ULID for the given time: a 48 bit millisecond
+// timestamp followed by 80 random bits, encoded as 26 characters.
+func newULID(t time.Time) string {
+	var id [16]byte
+	ms := uint64(t.UnixNano() / int64(time.Millisecond))
+	binary.BigEndian.PutUint16(id[0:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(id[2:6], uint32(ms))
+	randomBytes(id[6:])
+
+	// The 128 bits are encoded 5 at a time, preceded by 2 zero bits.
+	hi := binary.BigEndian.Uint64(id[0:8])
+	lo := binary.BigEndian.Uint64(id[8:16])
+	var out [26]byte
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
@@ -0,0 +1,33 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var frameIDPattern = regexp.MustCompile(`\[meta frameId="([0-9A-HJKMNP-TV-Z]{26})"\]`)
+
+func Test_frame_id(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithFrameID())
+
+	l.Log(log_level.INFO, "", nil, "first")
+	time.Sleep(2 * time.Millisecond)
+	l.Log(log_level.INFO, "", nil, "second")
+
+	matches := frameIDPattern.FindAllStringSubmatch(buf.String(), -1)
+	if len(matches) != 2 {
+		t.Fatalf("got frame ids: %v, but expected 2 well-formed ids in: %s", matches, buf.String())
+	}
+	first, second := matches[0][1], matches[1][1]
+	if first[0] > '7' {
+		t.Fatalf("got frame id: %s, but the first character may not exceed 7", first)
+	}
+	if !(first < second) {
+		t.Fatalf("got frame ids: %s and %s, but expected them to sort by time", first, second)
+	}
+}
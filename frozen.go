@@ -0,0 +1,51 @@
+package syslog
+
+// FrozenStructuredData is a read-only view of StructuredData. It is
+// useful as a shared template that must not be changed by the code
+// that logs with it.
+type FrozenStructuredData struct {
+	sd StructuredData
+}
+
+// Freeze returns a read-only copy of the structured data. Later
+// changes to d are not reflected in the returned copy.
+func (d StructuredData) Freeze() FrozenStructuredData {
+	return FrozenStructuredData{d.Clone()}
+}
+
+// Element returns a read-only view of the SDElement associated with
+// the given id. The element is empty if it does not exist.
+func (d FrozenStructuredData) Element(id string) FrozenSDElement {
+	return FrozenSDElement{d.sd[id]}
+}
+
+// Ids returns the ids of the SDElements in lexicographical order.
+func (d FrozenStructuredData) Ids() []string {
+	return d.sd.Ids()
+}
+
+// String returns the string representation of the structured data.
+func (d FrozenStructuredData) String() string {
+	return d.sd.String()
+}
+
+// Thaw returns a modifiable copy of the structured data, for example
+// to extend a template before logging it.
+func (d FrozenStructuredData) Thaw() StructuredData {
+	return d.sd.Clone()
+}
+
+// FrozenSDElement is a read-only view of an SDElement.
+type FrozenSDElement struct {
+	elem SDElement
+}
+
+// Get returns a value associated with the specified name.
+func (e FrozenSDElement) Get(name string) string {
+	return e.elem.Get(name)
+}
+
+// Names returns the parameter names in lexicographical order.
+func (e FrozenSDElement) Names() []string {
+	return e.elem.Names()
+}
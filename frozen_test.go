@@ -0,0 +1,29 @@
+package syslog_test
+
+import (
+	"github.com/confetti-framework/syslog"
+	"testing"
+)
+
+func Test_frozen_structured_data(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+	frozen := sd.Freeze()
+
+	sd.Element("id1").Set("par1", "changed")
+	sd.Element("id2").Set("par2", "val2")
+
+	thawed := frozen.Thaw()
+	thawed.Element("id1").Set("par1", "changed")
+
+	expectedString := `[id1 par1="val1"]`
+	if frozen.String() != expectedString {
+		t.Fatalf("got string: %v, but expected: %v", frozen.String(), expectedString)
+	}
+	if value := frozen.Element("id1").Get("par1"); value != "val1" {
+		t.Fatalf("got value: %v, but expected: %v", value, "val1")
+	}
+	if names := frozen.Element("missing").Names(); len(names) != 0 {
+		t.Fatalf("got names: %v, but expected none", names)
+	}
+}
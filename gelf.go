@@ -0,0 +1,86 @@
+package syslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/confetti-framework/syslog/log_level"
+	"strconv"
+	"time"
+)
+
+// gelfVersion is the version of the GELF specification that is written.
+const gelfVersion = "1.1"
+
+// NewGELFFormatter returns a Formatter that writes messages in the
+// Graylog Extended Log Format, as one JSON object per line. The
+// severity becomes the GELF level, which uses the same numbers, and
+// the message the short_message. The app name, procid and msgid are
+// stored in the _app_name, _procid and _msgid fields, and every SD
+// param in a field named _sdid_param:
+//
+//	{"_app_name":"appName","_id1_par1":"val1","_msgid":"LoginFailed","_procid":"procid","host":"hostname","level":3,"short_message":"login failed","timestamp":1614834367.000,"version":"1.1"}
+//
+// Characters that are not allowed in GELF field names, such as the @
+// of an SD-ID, are replaced by an underscore. For GELF over TCP, which
+// delimits messages by a NUL byte, combine it with WithNULDelimiter.
+func NewGELFFormatter() Formatter {
+	return gelfFormatter{}
+}
+
+type gelfFormatter struct{}
+
+func (gelfFormatter) Format(
+	pri log_level.Priority,
+	timestamp time.Time,
+	hostname string,
+	appName string,
+	procid string,
+	msgid string,
+	sd StructuredData,
+	msg []byte,
+) []byte {
+	payload := map[string]interface{}{
+		"version":       gelfVersion,
+		"host":          defaultIfEmpty(hostname, "-"),
+		"short_message": string(bytes.TrimSuffix(msg, nl)),
+		"level":         int(pri.Severity()),
+		"timestamp":     json.Number(gelfTimestamp(timestamp)),
+	}
+	for name, value := range map[string]string{"_app_name": appName, "_procid": procid, "_msgid": msgid} {
+		if value != "" {
+			payload[name] = value
+		}
+	}
+	for _, id := range sd.Ids() {
+		elem := sd[id]
+		for _, name := range elem.Names() {
+			payload[gelfFieldName("_"+id+"_"+name)] = elem[name]
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	// a map of strings and numbers can always be encoded
+	_ = enc.Encode(payload)
+	return buf.Bytes()
+}
+
+// gelfTimestamp returns the seconds since the Unix epoch with
+// millisecond precision.
+func gelfTimestamp(t time.Time) string {
+	ms := t.UnixNano() / int64(time.Millisecond)
+	return strconv.FormatFloat(float64(ms)/1000, 'f', 3, 64)
+}
+
+// gelfFieldName replaces the characters that are not allowed in the
+// name of a GELF field by an underscore.
+func gelfFieldName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-') {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
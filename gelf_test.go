@@ -0,0 +1,51 @@
+package syslog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_gelf_formatter(t *testing.T) {
+	ts := time.Date(2021, 3, 4, 5, 6, 7, 5e6, time.UTC)
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithFormatter(syslog.NewGELFFormatter()), syslog.WithClock(func() time.Time { return ts }))
+
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+	sd.Element("origin@32473").Set("ip", "10.0.0.1")
+	l.Log(log_level.ERROR, "LoginFailed", sd, "login failed")
+
+	if !strings.HasSuffix(buf.String(), "}\n") || strings.Count(buf.String(), "\n") != 1 {
+		t.Fatalf("non-expected output: %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), `"timestamp":1614834367.005`) {
+		t.Fatalf("got: %q, but expected the timestamp in seconds", buf.String())
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("got error: %v, but expected a JSON object", err)
+	}
+	expected := map[string]interface{}{
+		"version":          "1.1",
+		"host":             "hostname",
+		"short_message":    "login failed",
+		"level":            float64(3),
+		"timestamp":        1614834367.005,
+		"_app_name":        "appName",
+		"_procid":          "procid",
+		"_msgid":           "LoginFailed",
+		"_id1_par1":        "val1",
+		"_origin_32473_ip": "10.0.0.1",
+	}
+	if !reflect.DeepEqual(payload, expected) {
+		t.Fatalf("got payload: %v, but expected: %v", payload, expected)
+	}
+}
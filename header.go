@@ -0,0 +1,157 @@
+package syslog
+
+import (
+	"fmt"
+	"github.com/confetti-framework/syslog/log_level"
+	"os"
+	"strconv"
+)
+
+// The maximum lengths of the header fields defined in RFC 5424.
+const (
+	maxHostnameLength = 255
+	maxAppNameLength  = 48
+	maxProcIDLength   = 128
+	maxMsgIDLength    = 32
+)
+
+// ValidateHeader reports whether the header fields can be written as
+// defined in RFC 5424: they may only contain printable US-ASCII
+// characters other than space, and may not exceed 255 (hostname),
+// 48 (appName), 128 (procid) and 32 (msgid) characters. Empty fields
+// are valid, they are written as the NILVALUE.
+//
+// Messages with invalid header fields are still written: characters
+// that are not allowed are replaced by an underscore and fields that
+// are too long are truncated.
+func ValidateHeader(hostname, appName, procid, msgid string) error {
+	for _, f := range []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"hostname", hostname, maxHostnameLength},
+		{"app name", appName, maxAppNameLength},
+		{"procid", procid, maxProcIDLength},
+		{"msgid", msgid, maxMsgIDLength},
+	} {
+		if len(f.value) > f.max {
+			return fmt.Errorf("syslog: %s %q exceeds %d characters", f.name, f.value, f.max)
+		}
+		for i := 0; i < len(f.value); i++ {
+			if !isPrintUSASCII(f.value[i]) {
+				return fmt.Errorf("syslog: %s %q contains invalid character %q", f.name, f.value, f.value[i])
+			}
+		}
+	}
+	return nil
+}
+
+// isPrintUSASCII reports whether c is allowed in a header field.
+func isPrintUSASCII(c byte) bool {
+	return c >= 33 && c <= 126
+}
+
+// sanitizeHeaderField replaces the characters that are not allowed in
+// a header field by an underscore and truncates it to max characters.
+// A field without any allowed character is returned empty, to be
+// written as the NILVALUE.
+func sanitizeHeaderField(s string, max int) string {
+	valid := len(s) <= max
+	for i := 0; valid && i < len(s); i++ {
+		valid = isPrintUSASCII(s[i])
+	}
+	if valid {
+		return s
+	}
+
+	b := make([]byte, 0, len(s))
+	empty := true
+	for _, r := range s {
+		if len(b) == max {
+			break
+		}
+		if r < 128 && isPrintUSASCII(byte(r)) {
+			b = append(b, byte(r))
+			empty = false
+		} else {
+			b = append(b, '_')
+		}
+	}
+	if empty {
+		return ""
+	}
+	return string(b)
+}
+
+// DefaultHostname returns the host name reported by the kernel, or an
+// empty string, which is written as the NILVALUE, if it can't be
+// determined.
+func DefaultHostname() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return hostname
+}
+
+// DefaultProcID returns the process id of the caller.
+func DefaultProcID() string {
+	return strconv.Itoa(os.Getpid())
+}
+
+// WithAutoDetect fills an empty hostname with DefaultHostname and an
+// empty procid with DefaultProcID when the writer or logger is
+// created. When the hostname can't be determined it is still written
+// as the NILVALUE.
+func WithAutoDetect() Option {
+	return func(o *options) {
+		o.autoDetect = true
+	}
+}
+
+// detect returns the hostname and procid of a new writer or logger.
+func (o *options) detect(hostname, procid string) (string, string) {
+	if !o.autoDetect {
+		return hostname, procid
+	}
+	if hostname == "" {
+		hostname = DefaultHostname()
+	}
+	if procid == "" {
+		procid = DefaultProcID()
+	}
+	return hostname, procid
+}
+
+// WithFacility sets the facility of the messages of a logger created
+// by New.
+func WithFacility(facility log_level.Priority) Option {
+	return func(o *options) {
+		o.facility = facility
+	}
+}
+
+// WithHostname sets the HOSTNAME of the messages of a logger created
+// by New.
+func WithHostname(hostname string) Option {
+	return func(o *options) {
+		o.hostname = hostname
+	}
+}
+
+// WithAppName sets the APP-NAME of the messages of a logger created
+// by New.
+func WithAppName(appName string) Option {
+	return func(o *options) {
+		o.appName = appName
+	}
+}
+
+// WithProcID sets the PROCID of the messages of a logger created by
+// New.
+func WithProcID(procid string) Option {
+	return func(o *options) {
+		o.procid = procid
+	}
+}
@@ -0,0 +1,70 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_validate_header(t *testing.T) {
+	if err := syslog.ValidateHeader("laptop", "testapp", "123", "LoginFailed"); err != nil {
+		t.Fatalf("expected valid header, got: %v", err)
+	}
+	if err := syslog.ValidateHeader("", "", "", ""); err != nil {
+		t.Fatalf("expected empty header fields to be valid, got: %v", err)
+	}
+	for _, header := range [][4]string{
+		{"my laptop", "testapp", "123", "msgid"},
+		{"laptop", "tëstapp", "123", "msgid"},
+		{"laptop", strings.Repeat("a", 49), "123", "msgid"},
+		{"laptop", "testapp", "123", strings.Repeat("a", 33)},
+	} {
+		if err := syslog.ValidateHeader(header[0], header[1], header[2], header[3]); err == nil {
+			t.Fatalf("expected an error for header: %q", header)
+		}
+	}
+}
+
+func Test_header_fields_are_sanitized(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "my laptop", strings.Repeat("a", 50), "\t")
+	l.Log(log_level.ERROR, "login\nfailed", nil, "message")
+
+	expectedSuffix := " my_laptop " + strings.Repeat("a", 48) + " - login_failed - message\n"
+	if !strings.HasSuffix(buf.String(), expectedSuffix) {
+		t.Fatalf("got: %q, but expected suffix: %q", buf.String(), expectedSuffix)
+	}
+}
+
+func Test_auto_detect(t *testing.T) {
+	hostname, _ := os.Hostname()
+	pid := strconv.Itoa(os.Getpid())
+	if syslog.DefaultHostname() != hostname || syslog.DefaultProcID() != pid {
+		t.Fatalf("got: %v and %v, but expected: %v and %v", syslog.DefaultHostname(), syslog.DefaultProcID(), hostname, pid)
+	}
+
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "", "appName", "", syslog.WithAutoDetect())
+	l.Log(log_level.ERROR, "", nil, "message")
+
+	expected := " " + syslog.DefaultHostname() + " appName " + pid + " - - message\n"
+	if hostname == "" {
+		expected = " - appName " + pid + " - - message\n"
+	}
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Fatalf("got: %q, but expected suffix: %q", buf.String(), expected)
+	}
+}
+
+func Test_auto_detect_keeps_given_values(t *testing.T) {
+	buf := &bytes.Buffer{}
+	syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithAutoDetect()).Write([]byte("message"))
+
+	if !strings.HasSuffix(buf.String(), " laptop testapp 123 - - message\n") {
+		t.Fatalf("non-expected output: %q", buf.String())
+	}
+}
@@ -0,0 +1,37 @@
+package syslog
+
+import (
+	"errors"
+	"github.com/confetti-framework/syslog/log_level"
+)
+
+// ErrDropped is passed to the after hook when a message is not
+// written because it was dropped by a filter.
+var ErrDropped = errors.New("syslog: message dropped")
+
+// WithHooks registers functions that are called before and after
+// every message is written. The after hook receives the number of
+// bytes written and the write error. When the message is dropped,
+// for example because of its severity, the after hook still runs and
+// receives ErrDropped. Either hook may be nil.
+func WithHooks(
+	before func(severity log_level.Priority, msgId string),
+	after func(severity log_level.Priority, n int, err error),
+) Option {
+	return func(o *options) {
+		o.before = before
+		o.after = after
+	}
+}
+
+func (o *options) beforeWrite(severity log_level.Priority, msgId string) {
+	if o.before != nil {
+		o.before(severity, msgId)
+	}
+}
+
+func (o *options) afterWrite(severity log_level.Priority, n int, err error) {
+	if o.after != nil {
+		o.after(severity, n, err)
+	}
+}
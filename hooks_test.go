@@ -0,0 +1,59 @@
+package syslog_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"testing"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func Test_hooks(t *testing.T) {
+	var calls []string
+	var afterSeverity log_level.Priority
+	var afterN int
+	var afterErr error
+
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithHooks(
+		func(severity log_level.Priority, msgId string) {
+			calls = append(calls, "before")
+			if severity != log_level.ERROR || msgId != "LoginFailed" {
+				t.Fatalf("got severity: %v and msgId: %v in before hook", severity, msgId)
+			}
+		},
+		func(severity log_level.Priority, n int, err error) {
+			calls = append(calls, "after")
+			afterSeverity, afterN, afterErr = severity, n, err
+		},
+	))
+	l.Log(log_level.ERROR, "LoginFailed", nil, "login failed")
+
+	if len(calls) != 2 || calls[0] != "before" || calls[1] != "after" {
+		t.Fatalf("got calls: %v, but expected before and after", calls)
+	}
+	if afterSeverity != log_level.ERROR || afterN != buf.Len() || afterErr != nil {
+		t.Fatalf("got severity: %v, n: %v, err: %v in after hook", afterSeverity, afterN, afterErr)
+	}
+}
+
+func Test_hooks_receive_write_error(t *testing.T) {
+	var afterErr error
+	w := syslog.NewWriter(failingWriter{}, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithHooks(
+		nil,
+		func(severity log_level.Priority, n int, err error) {
+			afterErr = err
+		},
+	))
+	w.Write([]byte("message"))
+
+	if afterErr == nil || afterErr.Error() != "write failed" {
+		t.Fatalf("got error: %v, but expected the write error", afterErr)
+	}
+}
@@ -0,0 +1,101 @@
+package syslog
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	mathrand "math/rand"
+	"os"
+	"sync"
+	"time"
+)
+
+// IDGenerator generates the unique ids attached to messages, such as
+// the frame id added by WithFrameID.
+type IDGenerator interface {
+	NextID() string
+}
+
+// IDGeneratorFunc adapts an ordinary function to an IDGenerator.
+type IDGeneratorFunc func() string
+
+// NextID returns f().
+func (f IDGeneratorFunc) NextID() string {
+	return f()
+}
+
+// NewUUIDGenerator returns an IDGenerator of random (version 4) UUIDs,
+// for ids that have no ordering requirements:
+//
+//	syslog.WithIDGenerator(syslog.NewUUIDGenerator())
+func NewUUIDGenerator() IDGenerator {
+	return IDGeneratorFunc(newUUID)
+}
+
+// NewULIDGenerator returns an IDGenerator of ULIDs, which sort by the
+// time they were generated.
+func NewULIDGenerator() IDGenerator {
+	return IDGeneratorFunc(func() string {
+		return newULID(time.Now())
+	})
+}
+
+// WithIDGenerator replaces the generator of the ids attached to
+// messages, for example by NewUUIDGenerator or by one that generates
+// Snowflake ids. Without it, frame ids are ULIDs of the timestamp of
+// the message.
+func WithIDGenerator(g IDGenerator) Option {
+	return func(o *options) {
+		o.idGenerator = g
+	}
+}
+
+// newUUID returns a random UUID as defined in RFC 4122, section 4.4.
+func newUUID() string {
+	var id [16]byte
+	randomBytes(id[:])
+	id[6] = id[6]&0x0f | 0x40 // version 4
+	id[8] = id[8]&0x3f | 0x80 // variant 10
+
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], id[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], id[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], id[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], id[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], id[10:])
+	return string(buf)
+}
+
+// fallbackRand is the source of randomBytes when crypto/rand fails.
+// It is seeded from the time and the process id, so processes that
+// start at the same time don't generate the same ids.
+var fallbackRand struct {
+	once sync.Once
+	mu   sync.Mutex
+	r    *mathrand.Rand
+}
+
+// randomBytes fills b with random bytes from crypto/rand. Should the
+// system source fail, it falls back to math/rand seeded from the time
+// and the process id: ids are then likely but not guaranteed to be
+// unique, and no longer unpredictable.
+func randomBytes(b []byte) {
+	if _, err := rand.Read(b); err == nil {
+		return
+	}
+	fallbackRand.once.Do(func() {
+		seed := time.Now().UnixNano() ^ int64(os.Getpid())<<32
+		fallbackRand.r = mathrand.New(mathrand.NewSource(seed))
+	})
+	fallbackRand.mu.Lock()
+	defer fallbackRand.mu.Unlock()
+	for i := 0; i < len(b); i += 8 {
+		var chunk [8]byte
+		binary.LittleEndian.PutUint64(chunk[:], fallbackRand.r.Uint64())
+		copy(b[i:], chunk[:])
+	}
+}
@@ -0,0 +1,62 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_id_generator(t *testing.T) {
+	next := 0
+	generator := syslog.IDGeneratorFunc(func() string {
+		next++
+		return "id-" + strconv.Itoa(next)
+	})
+
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithFrameID(), syslog.WithIDGenerator(generator))
+	l.Log(log_level.INFO, "", nil, "first")
+	l.Log(log_level.INFO, "", nil, "second")
+
+	lines := strings.Split(buf.String(), "\n")
+	if !strings.HasSuffix(lines[0], `[meta frameId="id-1"] first`) {
+		t.Fatalf("non-expected message: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], `[meta frameId="id-2"] second`) {
+		t.Fatalf("non-expected message: %s", lines[1])
+	}
+}
+
+func Test_uuid_generator(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	generator := syslog.NewUUIDGenerator()
+	first, second := generator.NextID(), generator.NextID()
+
+	if !pattern.MatchString(first) {
+		t.Fatalf("got malformed UUID: %s", first)
+	}
+	if first == second {
+		t.Fatalf("got the same UUID twice: %s", first)
+	}
+}
+
+func Test_ulid_generator(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`)
+	generator := syslog.NewULIDGenerator()
+	first := generator.NextID()
+	time.Sleep(2 * time.Millisecond)
+	second := generator.NextID()
+
+	if !pattern.MatchString(first) {
+		t.Fatalf("got malformed ULID: %s", first)
+	}
+	if second <= first {
+		t.Fatalf("got ULID: %s, but expected it to sort after: %s", second, first)
+	}
+}
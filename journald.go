@@ -0,0 +1,123 @@
+package syslog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// journaldSocket is the socket of the native protocol of journald.
+const journaldSocket = "/run/systemd/journal/socket"
+
+// JournaldWriter sends messages to journald with its native protocol,
+// so their structured data stays available as fields in journalctl.
+type JournaldWriter struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewJournaldWriter connects to the native protocol socket of journald
+// and returns a JournaldWriter. It accepts the RFC 5424 messages
+// written by a Writer or Logger and sends every message as a datagram
+// with the fields:
+//
+//	PRIORITY           the severity
+//	SYSLOG_FACILITY    the facility code
+//	SYSLOG_IDENTIFIER  the app name
+//	SYSLOG_PID         the procid
+//	MESSAGE            the message
+//
+// and a field named SDID_PARAM in upper case for every SD param, with
+// the characters that are not allowed in a field name replaced by an
+// underscore, so param ip of origin becomes ORIGIN_IP. Header fields
+// holding the NILVALUE are left out.
+//
+// It returns an error when the socket of journald is not available,
+// for example on hosts without systemd.
+// The returned JournaldWriter is safe for concurrent use by multiple
+// goroutines.
+func NewJournaldWriter() (*JournaldWriter, error) {
+	return newJournaldWriter(journaldSocket)
+}
+
+func newJournaldWriter(path string) (*JournaldWriter, error) {
+	conn, err := net.Dial("unixgram", path)
+	if err != nil {
+		return nil, fmt.Errorf("syslog: journald socket not available: %w", err)
+	}
+	return &JournaldWriter{conn: conn}, nil
+}
+
+// Write parses the syslog message p and sends it to journald.
+func (w *JournaldWriter) Write(p []byte) (int, error) {
+	m, err := Parse(p)
+	if err != nil {
+		return 0, err
+	}
+
+	buf := &bytes.Buffer{}
+	writeJournaldField(buf, "PRIORITY", strconv.Itoa(int(m.Severity())))
+	writeJournaldField(buf, "SYSLOG_FACILITY", strconv.Itoa(int(m.Facility()>>3)))
+	if m.AppName != "" {
+		writeJournaldField(buf, "SYSLOG_IDENTIFIER", m.AppName)
+	}
+	if m.ProcID != "" {
+		writeJournaldField(buf, "SYSLOG_PID", m.ProcID)
+	}
+	for _, id := range m.StructuredData.Ids() {
+		elem := m.StructuredData[id]
+		for _, name := range elem.Names() {
+			writeJournaldField(buf, journaldFieldName(id+"_"+name), elem[name])
+		}
+	}
+	writeJournaldField(buf, "MESSAGE", string(m.Msg))
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.conn.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the connection to journald.
+func (w *JournaldWriter) Close() error {
+	return w.conn.Close()
+}
+
+// writeJournaldField writes a field as KEY=value, or for a value that
+// holds a newline, as the key followed by a newline, the length of the
+// value as a 64 bit little-endian integer and the value.
+func writeJournaldField(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key)
+	if strings.IndexByte(value, '\n') < 0 {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	var size [8]byte
+	binary.LittleEndian.PutUint64(size[:], uint64(len(value)))
+	buf.WriteByte('\n')
+	buf.Write(size[:])
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journaldFieldName returns name in upper case, with the characters
+// that are not allowed in a journald field name replaced by an
+// underscore. Field names starting with an underscore are reserved
+// for journald, so leading underscores are removed.
+func journaldFieldName(name string) string {
+	b := []byte(strings.ToUpper(name))
+	for i, c := range b {
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			b[i] = '_'
+		}
+	}
+	return strings.TrimLeft(string(b), "_")
+}
@@ -0,0 +1,64 @@
+package syslog_test
+
+import (
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_journald_writer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journald")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "socket")
+	conn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skipf("unixgram not supported: %v", err)
+	}
+	defer conn.Close()
+
+	w, err := syslog.NewJournaldWriterPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	l := syslog.NewLogger(w, syslog.LOCAL0, "hostname", "appName", "123")
+
+	sd := syslog.StructuredData{}
+	sd.Element("origin@32473").Set("ip", "10.0.0.1")
+	l.Log(log_level.ERROR, "", sd, "line 1\nline 2")
+
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "PRIORITY=3\n" +
+		"SYSLOG_FACILITY=16\n" +
+		"SYSLOG_IDENTIFIER=appName\n" +
+		"SYSLOG_PID=123\n" +
+		"ORIGIN_32473_IP=10.0.0.1\n" +
+		"MESSAGE\n\x0d\x00\x00\x00\x00\x00\x00\x00line 1\nline 2\n"
+	if string(buf[:n]) != expected {
+		t.Fatalf("got datagram: %q, but expected: %q", buf[:n], expected)
+	}
+}
+
+func Test_journald_writer_without_socket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journald")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := syslog.NewJournaldWriterPath(filepath.Join(dir, "missing")); err == nil {
+		t.Fatalf("got error: <nil>, but expected an error for a missing socket")
+	}
+}
@@ -0,0 +1,43 @@
+package syslog
+
+import "sync/atomic"
+
+// lastError holds the error of the most recent write. It is safe for
+// concurrent use.
+type lastError struct {
+	v atomic.Value
+}
+
+// errorValue wraps an error, since an atomic.Value can't store nil
+// and requires every stored value to have the same concrete type.
+type errorValue struct {
+	err error
+}
+
+// store records the result of a write. A nil error clears the
+// previous error, a dropped message leaves it as it is.
+func (e *lastError) store(err error) {
+	if err == ErrDropped {
+		return
+	}
+	e.v.Store(errorValue{err})
+}
+
+func (e *lastError) load() error {
+	v, _ := e.v.Load().(errorValue)
+	return v.err
+}
+
+// LastError returns the error of the most recent write, or nil when
+// the most recent write succeeded. Dropped messages don't change the
+// last error.
+func (w *Writer) LastError() error {
+	return w.lastErr.load()
+}
+
+// LastError returns the error of the most recent message, or nil when
+// the most recent message was written successfully. Dropped messages
+// don't change the last error.
+func (l *WriterLogger) LastError() error {
+	return l.lastErr.load()
+}
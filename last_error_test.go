@@ -0,0 +1,78 @@
+package syslog_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"testing"
+)
+
+// toggleWriter fails every write while err is set.
+type toggleWriter struct {
+	bytes.Buffer
+	err error
+}
+
+func (w *toggleWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.Buffer.Write(p)
+}
+
+func Test_writer_last_error(t *testing.T) {
+	out := &toggleWriter{err: errors.New("disk full")}
+	w := syslog.NewWriter(out, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123")
+
+	if err := w.LastError(); err != nil {
+		t.Fatalf("got error: %v, but expected nil before the first write", err)
+	}
+
+	w.Write([]byte("message"))
+	if err := w.LastError(); err != out.err {
+		t.Fatalf("got error: %v, but expected: %v", err, out.err)
+	}
+
+	out.err = nil
+	w.Write([]byte("message"))
+	if err := w.LastError(); err != nil {
+		t.Fatalf("got error: %v, but expected nil after a successful write", err)
+	}
+}
+
+func Test_logger_last_error(t *testing.T) {
+	out := &toggleWriter{err: errors.New("disk full")}
+	l := syslog.NewLogger(out, syslog.USER, "hostname", "appName", "procid")
+
+	l.Log(log_level.ERROR, "LoginFailed", nil, "login failed")
+	if err := l.LastError(); err != out.err {
+		t.Fatalf("got error: %v, but expected: %v", err, out.err)
+	}
+
+	out.err = nil
+	l.Log(log_level.ERROR, "LoginFailed", nil, "login failed")
+	if err := l.LastError(); err != nil {
+		t.Fatalf("got error: %v, but expected nil after a successful write", err)
+	}
+}
+
+func Test_log_returns_write_error(t *testing.T) {
+	out := &toggleWriter{err: errors.New("connection refused")}
+	l := syslog.NewLogger(out, syslog.USER, "hostname", "appName", "procid")
+
+	if err := l.Log(log_level.ERROR, "", nil, "lost"); err != out.err {
+		t.Fatalf("got error: %v, but expected: %v", err, out.err)
+	}
+	if err := syslog.Info(l, "", nil, "lost"); err != out.err {
+		t.Fatalf("got error: %v from Info, but expected: %v", err, out.err)
+	}
+
+	out.err = nil
+	if err := syslog.Error(l, "", nil, "delivered"); err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+	if err := syslog.Error(nil, "", nil, "no logger"); err != nil {
+		t.Fatalf("got error: %v for a nil logger, but expected nil", err)
+	}
+}
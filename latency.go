@@ -0,0 +1,47 @@
+package syslog
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// WithEmissionLatency measures the time between the start of logging
+// a message and the completion of its write. A frame cannot contain
+// its own latency, so the latency of the previous successfully written
+// message is added to the next one as the emitLatencyNs param of the
+// meta element. The first message has no latency. The latency is
+// measured with the wall clock, also when WithClock is used.
+func WithEmissionLatency() Option {
+	return func(o *options) {
+		o.emitLatency = &emissionLatency{}
+	}
+}
+
+// emissionLatency holds the latency in nanoseconds of the last
+// message, it is accessed atomically.
+type emissionLatency struct {
+	last int64
+}
+
+func (e *emissionLatency) load() int64 {
+	if e == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&e.last)
+}
+
+// record stores the latency of a message of which the logging started
+// at start.
+func (e *emissionLatency) record(start time.Time) {
+	if e == nil {
+		return
+	}
+	atomic.StoreInt64(&e.last, int64(time.Since(start)))
+}
+
+func (o *options) setEmissionLatency(sd StructuredData) {
+	if ns := o.emitLatency.load(); ns > 0 {
+		sd.Element(metaElement).Set("emitLatencyNs", strconv.FormatInt(ns, 10))
+	}
+}
@@ -0,0 +1,55 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type slowWriter struct {
+	bytes.Buffer
+	delay time.Duration
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return w.Buffer.Write(p)
+}
+
+func Test_emission_latency(t *testing.T) {
+	out := &slowWriter{delay: 2 * time.Millisecond}
+	l := syslog.NewLogger(out, syslog.USER, "hostname", "appName", "procid", syslog.WithEmissionLatency())
+
+	l.Log(log_level.INFO, "", nil, "first")
+	l.Log(log_level.INFO, "", nil, "second")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if strings.Contains(lines[0], "emitLatencyNs") {
+		t.Fatalf("got: %q, but expected no latency in the first message", lines[0])
+	}
+	match := regexp.MustCompile(`\[meta emitLatencyNs="(\d+)"\] second`).FindStringSubmatch(lines[1])
+	if match == nil {
+		t.Fatalf("got: %q, but expected the latency of the first message", lines[1])
+	}
+	ns, _ := strconv.ParseInt(match[1], 10, 64)
+	if ns < int64(2*time.Millisecond) || ns > int64(time.Minute) {
+		t.Fatalf("got latency: %v, but expected at least: %v", time.Duration(ns), 2*time.Millisecond)
+	}
+}
+
+func Test_emission_latency_writer(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.INFO, "hostname", "appName", "procid", syslog.WithEmissionLatency())
+	w.Write([]byte("first"))
+	w.Write([]byte("second"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !regexp.MustCompile(`\[meta emitLatencyNs="[1-9]\d*"\] second$`).MatchString(lines[1]) {
+		t.Fatalf("got: %q, but expected the latency of the first message", lines[1])
+	}
+}
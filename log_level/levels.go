@@ -1,5 +1,7 @@
 package log_level
 
+import "strconv"
+
 type Level = Priority // severity
 
 // The Priority is a combination of the syslog facility and
@@ -18,3 +20,58 @@ const (
 	INFO
 	DEBUG
 )
+
+const severityMask = 0x07
+
+// Facility returns the facility part of the priority, for example
+// USER for USER|NOTICE.
+func (p Priority) Facility() Priority {
+	return p &^ severityMask
+}
+
+// Severity returns the severity part of the priority, for example
+// NOTICE for USER|NOTICE.
+func (p Priority) Severity() Priority {
+	return p & severityMask
+}
+
+// maxPriority is the largest valid priority: facility LOCAL7 with
+// severity DEBUG.
+const maxPriority = 23<<3 | DEBUG
+
+// Valid reports whether the priority is a severity of 0 to 7 combined
+// with a facility of 0 to 23, the range defined by RFC 5424.
+func (p Priority) Valid() bool {
+	return p >= 0 && p <= maxPriority
+}
+
+var severityNames = [...]string{
+	EMERGENCY: "EMERGENCY",
+	ALERT:     "ALERT",
+	CRITICAL:  "CRITICAL",
+	ERROR:     "ERROR",
+	WARNING:   "WARNING",
+	NOTICE:    "NOTICE",
+	INFO:      "INFO",
+	DEBUG:     "DEBUG",
+}
+
+// facilityNames holds the names of the facilities by their code,
+// which is the facility shifted right by 3 bits. Codes 12 to 15 have
+// no name.
+var facilityNames = [...]string{
+	"KERN", "USER", "MAIL", "DAEMON", "AUTH", "SYSLOG", "LPR", "NEWS",
+	"UUCP", "CRON", "AUTHPRIV", "FTP", "", "", "", "",
+	"LOCAL0", "LOCAL1", "LOCAL2", "LOCAL3", "LOCAL4", "LOCAL5", "LOCAL6", "LOCAL7",
+}
+
+// String returns the names of the facility and severity of the
+// priority, for example "USER|NOTICE". Priorities with an unknown
+// facility are returned as a number.
+func (p Priority) String() string {
+	code := int(p >> 3)
+	if p < 0 || code >= len(facilityNames) || facilityNames[code] == "" {
+		return strconv.Itoa(int(p))
+	}
+	return facilityNames[code] + "|" + severityNames[p.Severity()]
+}
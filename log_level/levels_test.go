@@ -0,0 +1,52 @@
+package log_level_test
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"testing"
+)
+
+// user and local7 mirror the facilities defined in the syslog package.
+const (
+	user   log_level.Priority = 1 << 3
+	local7 log_level.Priority = 23 << 3
+)
+
+func Test_facility_and_severity(t *testing.T) {
+	p := user | log_level.NOTICE
+
+	if p.Facility() != user {
+		t.Fatalf("got facility: %d, but expected: %d", p.Facility(), user)
+	}
+	if p.Severity() != log_level.NOTICE {
+		t.Fatalf("got severity: %d, but expected: %d", p.Severity(), log_level.NOTICE)
+	}
+}
+
+func Test_priority_string(t *testing.T) {
+	for p, expected := range map[log_level.Priority]string{
+		user | log_level.NOTICE:  "USER|NOTICE",
+		local7 | log_level.DEBUG: "LOCAL7|DEBUG",
+		log_level.EMERGENCY:      "KERN|EMERGENCY",
+		12<<3 | log_level.INFO:   "102",
+		24 << 3:                  "192",
+	} {
+		if p.String() != expected {
+			t.Fatalf("got string: %s, but expected: %s", p.String(), expected)
+		}
+	}
+}
+
+func Test_priority_valid(t *testing.T) {
+	for p, expected := range map[log_level.Priority]bool{
+		user | log_level.NOTICE:  true,
+		local7 | log_level.DEBUG: true,
+		log_level.EMERGENCY:      true,
+		12<<3 | log_level.INFO:   true,
+		24 << 3:                  false,
+		-1:                       false,
+	} {
+		if p.Valid() != expected {
+			t.Fatalf("got valid %s: %v, but expected: %v", p, p.Valid(), expected)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package log_level
+
+import (
+	"fmt"
+	"strings"
+)
+
+// severityAliases holds the names used by syslog.conf and the
+// keywords of the syslog package that differ from severityNames.
+var severityAliases = map[string]Priority{
+	"EMERG": EMERGENCY,
+	"PANIC": EMERGENCY,
+	"CRIT":  CRITICAL,
+	"ERR":   ERROR,
+	"WARN":  WARNING,
+}
+
+// ParseSeverity returns the severity with the given name, for example
+// "warning", "warn" or "WARNING". Names are case-insensitive.
+func ParseSeverity(s string) (Priority, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	for severity, n := range severityNames {
+		if n == name {
+			return Priority(severity), nil
+		}
+	}
+	if severity, ok := severityAliases[name]; ok {
+		return severity, nil
+	}
+	return 0, fmt.Errorf("log_level: unknown severity %q", s)
+}
+
+// ParseFacility returns the facility with the given name, for example
+// "local0" or "USER". Names are case-insensitive. "security" is
+// accepted as an alias of "auth".
+func ParseFacility(s string) (Priority, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	if name == "SECURITY" {
+		name = "AUTH"
+	}
+	if name != "" {
+		for code, n := range facilityNames {
+			if n == name {
+				return Priority(code << 3), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("log_level: unknown facility %q", s)
+}
+
+// ParsePriority returns the priority with the given facility and
+// severity, separated by a dot as in syslog.conf, for example
+// "local0.warning", or by a vertical bar as returned by String, for
+// example "LOCAL0|WARNING".
+func ParsePriority(s string) (Priority, error) {
+	i := strings.IndexAny(s, ".|")
+	if i < 0 {
+		return 0, fmt.Errorf("log_level: priority %q is not of the form facility.severity", s)
+	}
+	facility, err := ParseFacility(s[:i])
+	if err != nil {
+		return 0, err
+	}
+	severity, err := ParseSeverity(s[i+1:])
+	if err != nil {
+		return 0, err
+	}
+	return facility | severity, nil
+}
@@ -0,0 +1,63 @@
+package log_level_test
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"testing"
+)
+
+func Test_parse_severity(t *testing.T) {
+	for s, expected := range map[string]log_level.Priority{
+		"warning":   log_level.WARNING,
+		"WARN":      log_level.WARNING,
+		"emerg":     log_level.EMERGENCY,
+		"Emergency": log_level.EMERGENCY,
+		"err":       log_level.ERROR,
+		"debug":     log_level.DEBUG,
+	} {
+		severity, err := log_level.ParseSeverity(s)
+		if err != nil || severity != expected {
+			t.Fatalf("got severity: %v and error: %v for %q, but expected: %v", severity, err, s, expected)
+		}
+	}
+
+	if _, err := log_level.ParseSeverity("loud"); err == nil {
+		t.Fatalf("expected an error for an unknown severity")
+	}
+}
+
+func Test_parse_facility(t *testing.T) {
+	for s, expected := range map[string]log_level.Priority{
+		"local7": local7,
+		"USER":   user,
+		"kern":   0,
+	} {
+		facility, err := log_level.ParseFacility(s)
+		if err != nil || facility != expected {
+			t.Fatalf("got facility: %v and error: %v for %q, but expected: %v", facility, err, s, expected)
+		}
+	}
+
+	for _, s := range []string{"", "local8", "nothing"} {
+		if _, err := log_level.ParseFacility(s); err == nil {
+			t.Fatalf("expected an error for facility %q", s)
+		}
+	}
+}
+
+func Test_parse_priority(t *testing.T) {
+	p, err := log_level.ParsePriority("user.notice")
+	if err != nil || p != user|log_level.NOTICE {
+		t.Fatalf("got priority: %v and error: %v, but expected: %v", p, err, user|log_level.NOTICE)
+	}
+
+	p, err = log_level.ParsePriority((local7 | log_level.DEBUG).String())
+	if err != nil || p != local7|log_level.DEBUG {
+		t.Fatalf("got priority: %v and error: %v, but expected: %v", p, err, local7|log_level.DEBUG)
+	}
+
+	for _, s := range []string{"user", "user.loud", "nothing.notice"} {
+		if _, err := log_level.ParsePriority(s); err == nil {
+			t.Fatalf("expected an error for priority %q", s)
+		}
+	}
+}
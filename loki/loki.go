@@ -0,0 +1,295 @@
+// Package loki provides a writer that pushes log lines to the
+// Grafana Loki push API.
+package loki
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBatchSize is the number of lines a Writer collects before
+// pushing them to Loki.
+const DefaultBatchSize = 100
+
+// DefaultMaxPending is the number of lines a Writer keeps while Loki
+// can't be reached.
+const DefaultMaxPending = 100 * DefaultBatchSize
+
+// Bounds of the delay between the pushes after a failed push.
+const (
+	minRetryDelay = 100 * time.Millisecond
+	maxRetryDelay = 30 * time.Second
+)
+
+// Option configures a Writer.
+type Option func(*Writer)
+
+// WithBatchSize sets the number of lines collected before they are
+// pushed to Loki.
+func WithBatchSize(size int) Option {
+	return func(w *Writer) {
+		w.batchSize = size
+	}
+}
+
+// WithMaxPending sets the number of lines a Writer keeps while pushes
+// fail. When more lines are written, the oldest are dropped, see
+// Dropped.
+func WithMaxPending(max int) Option {
+	return func(w *Writer) {
+		w.maxPending = max
+	}
+}
+
+// WithHTTPClient sets the client used to push lines to Loki.
+func WithHTTPClient(client *http.Client) Option {
+	return func(w *Writer) {
+		w.client = client
+	}
+}
+
+// NewWriter returns a Writer that pushes every written line to the
+// Loki push endpoint, e.g. http://localhost:3100/loki/api/v1/push.
+// Lines are labeled with labels and with the hostname, app and
+// severity parsed from the syslog frame, and carry the TIMESTAMP of
+// the frame, only the MSG of the frame is pushed. Lines that aren't
+// RFC 5424 frames are pushed as they are, labeled with the severity
+// of pri and stamped with the time they are written.
+// Lines are pushed in batches, call Flush or Close to push the
+// pending lines. Lines that fail to push are kept and pushed with the
+// next batch, up to WithMaxPending lines. After a failed push, full
+// batches are pushed again with a delay that doubles up to 30 seconds,
+// Flush and Close push right away.
+// The returned Writer is safe for concurrent use by multiple
+// goroutines.
+func NewWriter(endpoint string, labels map[string]string, pri log_level.Priority, opts ...Option) *Writer {
+	w := &Writer{
+		endpoint:   endpoint,
+		labels:     labels,
+		severity:   pri.Severity(),
+		batchSize:  DefaultBatchSize,
+		maxPending: DefaultMaxPending,
+		client:     http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Writer pushes log lines to Loki.
+type Writer struct {
+	mu        sync.Mutex
+	endpoint  string
+	labels    map[string]string
+	severity  log_level.Priority
+	batchSize int
+	client    *http.Client
+	pending   []entry
+
+	maxPending int
+	dropped    int
+	retryDelay time.Duration
+	retryAt    time.Time
+
+	// pushMu serializes the pushes, which are made without holding mu,
+	// so the lines reach Loki in the order in which they were written.
+	pushMu sync.Mutex
+}
+
+// entry is a line waiting to be pushed.
+type entry struct {
+	key    string
+	labels map[string]string
+	value  [2]string
+}
+
+type pushRequest struct {
+	Streams []stream `json:"streams"`
+}
+
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// Write adds a line to the current batch and pushes the batch when
+// it is full.
+func (w *Writer) Write(d []byte) (int, error) {
+	line := bytes.TrimRight(d, "\n")
+	if len(line) == 0 {
+		return len(d), nil
+	}
+
+	labels, ts, msg := w.parse(line)
+	e := entry{
+		key:    labelsKey(labels),
+		labels: labels,
+		value:  [2]string{strconv.FormatInt(ts.UnixNano(), 10), string(msg)},
+	}
+
+	w.mu.Lock()
+	w.pending = append(w.pending, e)
+	w.trim()
+	var batch []entry
+	if len(w.pending) >= w.batchSize && !time.Now().Before(w.retryAt) {
+		batch = w.take()
+	}
+	w.mu.Unlock()
+
+	if err := w.push(batch); err != nil {
+		return len(d), err
+	}
+	return len(d), nil
+}
+
+// parse returns the labels, the timestamp and the message of a line.
+// The message of a syslog frame is its MSG, other lines are pushed as
+// they are.
+func (w *Writer) parse(line []byte) (map[string]string, time.Time, []byte) {
+	labels := make(map[string]string, len(w.labels)+3)
+	for name, value := range w.labels {
+		labels[name] = value
+	}
+
+	m, err := syslog.Parse(line)
+	if err != nil {
+		labels["severity"] = syslog.KeyBySeverity(w.severity)
+		return labels, time.Now(), line
+	}
+	labels["severity"] = syslog.KeyBySeverity(m.Severity())
+	if m.Hostname != "" {
+		labels["hostname"] = m.Hostname
+	}
+	if m.AppName != "" {
+		labels["app"] = m.AppName
+	}
+	ts := m.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	return labels, ts, m.Msg
+}
+
+// labelsKey returns a key that is equal for equal label sets.
+func labelsKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var key strings.Builder
+	for _, name := range names {
+		key.WriteString(strconv.Quote(name))
+		key.WriteByte('=')
+		key.WriteString(strconv.Quote(labels[name]))
+	}
+	return key.String()
+}
+
+// Flush pushes the pending lines to Loki.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	batch := w.take()
+	w.mu.Unlock()
+	return w.push(batch)
+}
+
+// Close pushes the pending lines to Loki.
+func (w *Writer) Close() error {
+	return w.Flush()
+}
+
+// Dropped returns the number of lines that were dropped because more
+// than WithMaxPending lines were waiting to be pushed.
+func (w *Writer) Dropped() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.dropped
+}
+
+// trim drops the oldest pending lines beyond maxPending. w.mu must be
+// held.
+func (w *Writer) trim() {
+	if n := len(w.pending) - w.maxPending; w.maxPending > 0 && n > 0 {
+		w.pending = append([]entry(nil), w.pending[n:]...)
+		w.dropped += n
+	}
+}
+
+// take removes the pending lines from the writer. w.mu must be held.
+func (w *Writer) take() []entry {
+	batch := w.pending
+	w.pending = nil
+	return batch
+}
+
+// push sends a batch to Loki without holding w.mu. When the push
+// fails, the lines are put back in front of the lines that were
+// written in the meantime and the next push of a full batch is
+// delayed.
+func (w *Writer) push(batch []entry) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	w.pushMu.Lock()
+	defer w.pushMu.Unlock()
+
+	err := w.post(batch)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err != nil {
+		w.pending = append(batch, w.pending...)
+		w.trim()
+		w.retryDelay *= 2
+		if w.retryDelay < minRetryDelay {
+			w.retryDelay = minRetryDelay
+		} else if w.retryDelay > maxRetryDelay {
+			w.retryDelay = maxRetryDelay
+		}
+		w.retryAt = time.Now().Add(w.retryDelay)
+		return err
+	}
+	w.retryDelay = 0
+	w.retryAt = time.Time{}
+	return nil
+}
+
+// post sends the lines of batch grouped in streams by their labels.
+func (w *Writer) post(batch []entry) error {
+	var streams []stream
+	index := map[string]int{}
+	for _, e := range batch {
+		i, ok := index[e.key]
+		if !ok {
+			i = len(streams)
+			index[e.key] = i
+			streams = append(streams, stream{Stream: e.labels})
+		}
+		streams[i].Values = append(streams[i].Values, e.value)
+	}
+	body, err := json.Marshal(pushRequest{Streams: streams})
+	if err != nil {
+		return err
+	}
+
+	resp, err := w.client.Post(w.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("loki: push failed: %s", resp.Status)
+	}
+	return nil
+}
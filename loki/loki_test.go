@@ -0,0 +1,222 @@
+package loki_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/confetti-framework/syslog/loki"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type pushRequest struct {
+	Streams []struct {
+		Stream map[string]string `json:"stream"`
+		Values [][2]string       `json:"values"`
+	} `json:"streams"`
+}
+
+func Test_writer(t *testing.T) {
+	var requests []pushRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req pushRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		requests = append(requests, req)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	w := loki.NewWriter(server.URL, map[string]string{"app": "testapp"}, syslog.USER|log_level.ERROR, loki.WithBatchSize(2))
+	w.Write([]byte("first\n"))
+	w.Write([]byte("second\n"))
+	w.Write([]byte("third\n"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, but expected 2", len(requests))
+	}
+
+	expectedLabels := map[string]string{"app": "testapp", "severity": "err"}
+	stream := requests[0].Streams[0]
+	if !reflect.DeepEqual(stream.Stream, expectedLabels) {
+		t.Fatalf("got labels: %v, but expected: %v", stream.Stream, expectedLabels)
+	}
+	if len(stream.Values) != 2 || stream.Values[0][1] != "first" || stream.Values[1][1] != "second" {
+		t.Fatalf("non-expected values: %v", stream.Values)
+	}
+	if stream.Values[0][0] == "" {
+		t.Fatalf("missing timestamp: %v", stream.Values)
+	}
+	if values := requests[1].Streams[0].Values; len(values) != 1 || values[0][1] != "third" {
+		t.Fatalf("non-expected values: %v", values)
+	}
+}
+
+func Test_writer_reports_push_failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	w := loki.NewWriter(server.URL, nil, syslog.USER|log_level.ERROR)
+	w.Write([]byte("message"))
+	if err := w.Close(); err == nil {
+		t.Fatal("expected an error for a failed push")
+	}
+}
+
+func Test_writer_labels_from_frame(t *testing.T) {
+	var requests []pushRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req pushRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		requests = append(requests, req)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	w := loki.NewWriter(server.URL, map[string]string{"env": "test"}, syslog.USER|log_level.ERROR)
+	w.Write([]byte("<11>1 2021-03-04T05:06:07.000008Z host app - - - failed\n"))
+	w.Write([]byte("<14>1 2021-03-04T05:06:08Z host app - - - started\n"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 1 || len(requests[0].Streams) != 2 {
+		t.Fatalf("got requests: %v, but expected 1 request with 2 streams", requests)
+	}
+	streams := requests[0].Streams
+	expectedLabels := map[string]string{"env": "test", "hostname": "host", "app": "app", "severity": "err"}
+	if !reflect.DeepEqual(streams[0].Stream, expectedLabels) {
+		t.Fatalf("got labels: %v, but expected: %v", streams[0].Stream, expectedLabels)
+	}
+	if streams[1].Stream["severity"] != "info" {
+		t.Fatalf("got severity: %v, but expected: info", streams[1].Stream["severity"])
+	}
+	if msg := streams[0].Values[0][1]; msg != "failed" {
+		t.Fatalf("got line: %q, but expected only the MSG of the frame", msg)
+	}
+	if ts := streams[0].Values[0][0]; ts != "1614834367000008000" {
+		t.Fatalf("got timestamp: %s, but expected: 1614834367000008000", ts)
+	}
+}
+
+func Test_writer_keeps_lines_after_failed_push(t *testing.T) {
+	fail := true
+	var requests []pushRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var req pushRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	w := loki.NewWriter(server.URL, nil, syslog.USER|log_level.ERROR)
+	w.Write([]byte("first\n"))
+	if err := w.Flush(); err == nil {
+		t.Fatal("expected an error for a failed push")
+	}
+
+	fail = false
+	w.Write([]byte("second\n"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, but expected 1", len(requests))
+	}
+	values := requests[0].Streams[0].Values
+	if len(values) != 2 || values[0][1] != "first" || values[1][1] != "second" {
+		t.Fatalf("got values: %v, but expected the failed line to be pushed again", values)
+	}
+}
+
+func Test_writer_does_not_block_writes_during_push(t *testing.T) {
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	w := loki.NewWriter(server.URL, nil, syslog.USER|log_level.ERROR)
+	w.Write([]byte("first\n"))
+	flushed := make(chan error)
+	go func() {
+		flushed <- w.Flush()
+	}()
+	<-received
+
+	written := make(chan struct{})
+	go func() {
+		w.Write([]byte("second\n"))
+		close(written)
+	}()
+	select {
+	case <-written:
+	case <-time.After(time.Second):
+		t.Fatal("expected Write not to wait for the pending push")
+	}
+
+	close(release)
+	if err := <-flushed; err != nil {
+		t.Fatal(err)
+	}
+	go func() { <-received }()
+	w.Close()
+}
+
+func Test_writer_caps_lines_and_backs_off_while_loki_fails(t *testing.T) {
+	fail := true
+	failed := 0
+	var requests []pushRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			failed++
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var req pushRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	w := loki.NewWriter(server.URL, nil, syslog.USER|log_level.ERROR, loki.WithBatchSize(2), loki.WithMaxPending(5))
+	for i := 1; i <= 20; i++ {
+		w.Write([]byte(fmt.Sprintf("line %d\n", i)))
+	}
+	if failed != 1 {
+		t.Fatalf("got %d failed pushes, but expected 1 before the retry delay passed", failed)
+	}
+	if w.Dropped() != 15 {
+		t.Fatalf("got %d dropped lines, but expected 15", w.Dropped())
+	}
+
+	fail = false
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	values := requests[0].Streams[0].Values
+	if len(values) != 5 || values[0][1] != "line 16" || values[4][1] != "line 20" {
+		t.Fatalf("got values: %v, but expected the newest 5 lines", values)
+	}
+}
@@ -0,0 +1,59 @@
+package syslog
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog/log_level"
+	"time"
+)
+
+// truncationMarker is appended to a message that is cut by
+// WithMaxSize.
+const truncationMarker = "..."
+
+// WithMaxSize limits the size of every formatted message to n octets,
+// including the trailing newline. When a message is too large, the
+// MSG is cut at a UTF-8 boundary and "..." is appended; the header and
+// structured data are never cut, so a message of which they alone
+// exceed n is still written larger than n. Common limits are 480 or
+// 2048 octets for UDP.
+//
+// Messages that are already formatted and relayed by a Writer are not
+// truncated.
+func WithMaxSize(n int) Option {
+	return func(o *options) {
+		o.maxSize = n
+	}
+}
+
+// format formats a message with f and truncates its MSG to fit the
+// size set by WithMaxSize.
+func (o *options) format(
+	buf *bytes.Buffer,
+	f Formatter,
+	pri log_level.Priority,
+	timestamp time.Time,
+	hostname string,
+	appName string,
+	procid string,
+	msgid string,
+	sd StructuredData,
+	msg []byte,
+) []byte {
+	frame := formatInto(buf, f, pri, timestamp, hostname, appName, procid, msgid, sd, msg)
+	excess := len(frame) - o.maxSize
+	if o.maxSize <= 0 || excess <= 0 {
+		return frame
+	}
+
+	// a trailing newline is not part of the MSG, formatters add it
+	// when it is missing
+	if len(msg) > 0 && msg[len(msg)-1] == '\n' {
+		msg = msg[:len(msg)-1]
+	}
+	keep := len(msg) - excess - len(truncationMarker)
+	if keep < 0 {
+		keep = 0
+	}
+	msg = append([]byte(truncate(string(msg), keep)), truncationMarker...)
+	return formatInto(buf, f, pri, timestamp, hostname, appName, procid, msgid, sd, msg)
+}
@@ -0,0 +1,59 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func Test_max_size(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithMaxSize(1024))
+	w.Write(bytes.Repeat([]byte("x"), 10*1024))
+
+	if buf.Len() != 1024 {
+		t.Fatalf("got length: %d, but expected: 1024", buf.Len())
+	}
+	m, err := syslog.Parse(buf.Bytes())
+	if err != nil {
+		t.Fatalf("got error: %v, but expected a clean header", err)
+	}
+	if m.Hostname != "laptop" || m.AppName != "testapp" || m.ProcID != "123" {
+		t.Fatalf("got header: %v %v %v, but expected: laptop testapp 123", m.Hostname, m.AppName, m.ProcID)
+	}
+	if !strings.HasSuffix(string(m.Msg), "xxx...") {
+		t.Fatalf("got message: %s, but expected the truncation marker", m.Msg)
+	}
+}
+
+func Test_max_size_keeps_utf8_and_structured_data(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithMaxSize(200))
+
+	sd := syslog.StructuredData{}
+	sd.Element("request@32473").Set("path", "/users")
+	l.Log(log_level.INFO, "", sd, strings.Repeat("€", 200))
+
+	if buf.Len() > 200 || !utf8.Valid(buf.Bytes()) {
+		t.Fatalf("got: %q, but expected at most 200 bytes of valid UTF-8", buf.String())
+	}
+	m, err := syslog.Parse(buf.Bytes())
+	if err != nil || m.StructuredData.Element("request@32473").Get("path") != "/users" {
+		t.Fatalf("got message: %v and error: %v, but expected the structured data", m, err)
+	}
+	if !strings.HasSuffix(string(m.Msg), "€...") {
+		t.Fatalf("got message: %s, but expected the truncation marker", m.Msg)
+	}
+}
+
+func Test_max_size_leaves_small_messages(t *testing.T) {
+	buf := &bytes.Buffer{}
+	syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithMaxSize(1024)).Write([]byte("message"))
+
+	if !strings.HasSuffix(buf.String(), " - - message\n") {
+		t.Fatalf("non-expected output: %s", buf.String())
+	}
+}
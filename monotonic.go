@@ -0,0 +1,40 @@
+package syslog
+
+import (
+	"sync"
+	"time"
+)
+
+// WithMonotonicTimestamps makes the timestamps of the messages
+// strictly increasing, for collectors that reject messages out of
+// order. A timestamp that is not after the timestamp of the previous
+// message, because of clock skew or a clock set by WithClock, is
+// replaced by the previous timestamp plus a nanosecond. At the default
+// precision of milliseconds, such messages are written with the same
+// timestamp as the previous one.
+func WithMonotonicTimestamps() Option {
+	return func(o *options) {
+		o.monotonic = &monotonicClock{}
+	}
+}
+
+type monotonicClock struct {
+	mu   sync.Mutex
+	last time.Time
+}
+
+// timestamp returns t, or the previous timestamp plus a nanosecond if
+// t is not after it.
+func (c *monotonicClock) timestamp(t time.Time) time.Time {
+	if c == nil {
+		return t
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.last.IsZero() && !t.After(c.last) {
+		t = c.last.Add(time.Nanosecond)
+	}
+	c.last = t
+	return t
+}
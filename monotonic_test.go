@@ -0,0 +1,74 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_monotonic_timestamps(t *testing.T) {
+	start := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	times := []time.Time{
+		start,
+		start.Add(-time.Second),
+		start.Add(time.Second),
+		start.Add(time.Second),
+		start.Add(500 * time.Millisecond),
+	}
+	clock := func() time.Time {
+		t := times[0]
+		times = times[1:]
+		return t
+	}
+
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithClock(clock), syslog.WithMonotonicTimestamps())
+	for range times {
+		l.Log(log_level.INFO, "", nil, "message")
+	}
+
+	expected := []string{
+		"2021-03-04T05:06:07+00:00",
+		"2021-03-04T05:06:07+00:00",
+		"2021-03-04T05:06:08+00:00",
+		"2021-03-04T05:06:08+00:00",
+		"2021-03-04T05:06:08+00:00",
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var previous time.Time
+	for i, line := range lines {
+		stamp := strings.Fields(line)[1]
+		if stamp != expected[i] {
+			t.Fatalf("got timestamp %d: %v, but expected: %v", i, stamp, expected[i])
+		}
+		ts, _ := time.Parse(time.RFC3339Nano, stamp)
+		if ts.Before(previous) {
+			t.Fatalf("got timestamp %d: %v before the previous: %v", i, ts, previous)
+		}
+		previous = ts
+	}
+}
+
+func Test_monotonic_timestamps_strictly_increase(t *testing.T) {
+	fixed := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	var stamps []time.Time
+	f := syslog.FormatterFunc(func(_ log_level.Priority, ts time.Time, _, _, _, _ string, _ syslog.StructuredData, msg []byte) []byte {
+		stamps = append(stamps, ts)
+		return msg
+	})
+	w := syslog.NewWriter(&bytes.Buffer{}, syslog.USER|log_level.INFO, "hostname", "appName", "procid",
+		syslog.WithClock(func() time.Time { return fixed }), syslog.WithMonotonicTimestamps(), syslog.WithFormatter(f))
+	for i := 0; i < 3; i++ {
+		w.Write([]byte("message"))
+	}
+
+	for i := 1; i < len(stamps); i++ {
+		if !stamps[i].After(stamps[i-1]) {
+			t.Fatalf("got timestamp %d: %v, but expected it after: %v", i, stamps[i], stamps[i-1])
+		}
+	}
+}
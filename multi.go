@@ -0,0 +1,53 @@
+package syslog
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+)
+
+// MultiError holds the errors of the destinations of a multi logger
+// that failed to log a message.
+type MultiError []error
+
+func (e MultiError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors, so errors.Is and errors.As inspect each
+// of them as of Go 1.20.
+func (e MultiError) Unwrap() []error {
+	return e
+}
+
+// NewMultiLogger returns a Logger that logs every message to all
+// loggers, each with its own formatting and options. A failing logger
+// doesn't stop the message from being delivered to the others: Log
+// returns a MultiError with the errors of all loggers that failed, or
+// nil if none failed.
+//
+// The loggers are called one after the other, in the given order, and
+// Log returns when all of them returned. A slow destination delays
+// the ones after it, so give destinations that may block, such as
+// network connections, a writer created by NewAsyncWriter.
+func NewMultiLogger(loggers ...Logger) Logger {
+	return multiLogger(append([]Logger(nil), loggers...))
+}
+
+type multiLogger []Logger
+
+func (m multiLogger) Log(severity log_level.Priority, msgId string, sd StructuredData, msgFormat string, a ...interface{}) error {
+	var errs MultiError
+	for _, l := range m {
+		if err := l.Log(severity, msgId, sd, msgFormat, a...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,51 @@
+package syslog_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+func Test_multi_logger(t *testing.T) {
+	file := &bytes.Buffer{}
+	remote := &bytes.Buffer{}
+	l := syslog.NewMultiLogger(
+		syslog.NewLogger(file, syslog.USER, "hostname", "appName", "procid"),
+		syslog.NewLogger(remote, syslog.USER, "hostname", "appName", "procid", syslog.WithFormatter(syslog.NewCEEFormatter())),
+	)
+
+	if err := l.Log(log_level.ERROR, "LoginFailed", nil, "login failed"); err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+	if !strings.HasSuffix(file.String(), " LoginFailed - login failed\n") {
+		t.Fatalf("non-expected file output: %s", file.String())
+	}
+	if !strings.HasSuffix(remote.String(), ` LoginFailed - @cee: {"msg":"login failed"}`+"\n") {
+		t.Fatalf("non-expected remote output: %s", remote.String())
+	}
+}
+
+func Test_multi_logger_combines_errors(t *testing.T) {
+	errFile := errors.New("disk full")
+	errRemote := errors.New("connection refused")
+	healthy := &bytes.Buffer{}
+	l := syslog.NewMultiLogger(
+		syslog.NewLogger(&toggleWriter{err: errFile}, syslog.USER, "hostname", "appName", "procid"),
+		syslog.NewLogger(healthy, syslog.USER, "hostname", "appName", "procid"),
+		syslog.NewLogger(&toggleWriter{err: errRemote}, syslog.USER, "hostname", "appName", "procid"),
+	)
+
+	err := l.Log(log_level.ERROR, "", nil, "message")
+	if err == nil || err.Error() != "disk full; connection refused" {
+		t.Fatalf("got error: %v, but expected both errors", err)
+	}
+	if errs, ok := err.(syslog.MultiError); !ok || len(errs) != 2 || errs[1] != errRemote {
+		t.Fatalf("got error: %#v, but expected a MultiError with both errors", err)
+	}
+	if !strings.HasSuffix(healthy.String(), " message\n") {
+		t.Fatalf("got output: %s, but expected the healthy logger to receive the message", healthy.String())
+	}
+}
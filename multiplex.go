@@ -0,0 +1,76 @@
+package syslog
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// TaggedEntry pairs a Formatter with the marker byte that tags the
+// frames it formats in the stream of a TaggedMultiplexWriter.
+type TaggedEntry struct {
+	Marker    byte
+	Formatter Formatter
+}
+
+// TaggedMultiplexWriter writes every message in several formats to a
+// single stream, tagging each frame with the marker of its format so a
+// reader can demultiplex the stream with ReadTaggedFrame.
+type TaggedMultiplexWriter struct {
+	out     io.Writer
+	entries []TaggedEntry
+}
+
+// NewTaggedMultiplexWriter returns a TaggedMultiplexWriter that writes
+// to out. It accepts the RFC 5424 messages written by a Writer or
+// Logger and, for every entry, formats the message with the formatter
+// of the entry and writes the marker, the length of the frame as a
+// 4 byte big-endian integer and the frame:
+//
+//	l := syslog.NewLogger(syslog.NewTaggedMultiplexWriter(out, []syslog.TaggedEntry{
+//		{'s', syslog.NewRFC5424Formatter()},
+//		{'j', syslog.NewCEEFormatter()},
+//	}), syslog.USER, "hostname", "appName", "procid")
+//
+// The frames of a message are written to out in a single write.
+func NewTaggedMultiplexWriter(out io.Writer, entries []TaggedEntry) *TaggedMultiplexWriter {
+	return &TaggedMultiplexWriter{out: out, entries: entries}
+}
+
+// Write parses the syslog message p and writes it in every format.
+func (w *TaggedMultiplexWriter) Write(p []byte) (int, error) {
+	m, err := Parse(p)
+	if err != nil {
+		return 0, err
+	}
+
+	var record []byte
+	for _, e := range w.entries {
+		frame := e.Formatter.Format(m.Priority, m.Timestamp, m.Hostname, m.AppName, m.ProcID, m.MsgID, m.StructuredData, m.Msg)
+		var header [5]byte
+		header[0] = e.Marker
+		binary.BigEndian.PutUint32(header[1:], uint32(len(frame)))
+		record = append(record, header[:]...)
+		record = append(record, frame...)
+	}
+	if _, err := w.out.Write(record); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ReadTaggedFrame reads the next frame written by a
+// TaggedMultiplexWriter from r and returns its marker.
+func ReadTaggedFrame(r io.Reader) (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	frame := make([]byte, binary.BigEndian.Uint32(header[1:]))
+	if _, err := io.ReadFull(r, frame); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, err
+	}
+	return header[0], frame, nil
+}
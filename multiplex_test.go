@@ -0,0 +1,62 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_tagged_multiplex_writer(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewTaggedMultiplexWriter(buf, []syslog.TaggedEntry{
+		{Marker: 's', Formatter: syslog.NewRFC5424Formatter()},
+		{Marker: 'j', Formatter: syslog.NewCEEFormatter()},
+	})
+	l := syslog.NewLogger(w, syslog.USER, "hostname", "appName", "procid")
+
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+	l.Log(log_level.ERROR, "LoginFailed", sd, "login failed")
+	l.Log(log_level.INFO, "", nil, "logged in")
+
+	frames := map[byte][]string{}
+	for {
+		marker, frame, err := syslog.ReadTaggedFrame(buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("got error: %v, but expected: <nil>", err)
+		}
+		frames[marker] = append(frames[marker], string(frame))
+	}
+
+	if len(frames['s']) != 2 || len(frames['j']) != 2 || len(frames) != 2 {
+		t.Fatalf("got frames: %v, but expected two of each format", frames)
+	}
+	expected := []string{
+		` hostname appName procid LoginFailed [id1 par1="val1"] login failed` + "\n",
+		` hostname appName procid LoginFailed - @cee: {"id1":{"par1":"val1"},"msg":"login failed"}` + "\n",
+	}
+	got := []string{frames['s'][0], frames['j'][0]}
+	for i := range got {
+		if !strings.HasPrefix(got[i], "<11>1 ") || !strings.HasSuffix(got[i], expected[i]) {
+			t.Fatalf("got frames: %q, but expected suffixes: %q", got, expected)
+		}
+	}
+	if !strings.HasSuffix(frames['j'][1], ` - - @cee: {"msg":"logged in"}`+"\n") {
+		t.Fatalf("got frame: %q, but expected the second message", frames['j'][1])
+	}
+}
+
+func Test_tagged_multiplex_writer_invalid_message(t *testing.T) {
+	w := syslog.NewTaggedMultiplexWriter(&bytes.Buffer{}, []syslog.TaggedEntry{
+		{Marker: 's', Formatter: syslog.NewRFC5424Formatter()},
+	})
+	if _, err := w.Write([]byte("not syslog")); err == nil {
+		t.Fatalf("got error: <nil>, but expected a parse error")
+	}
+}
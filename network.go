@@ -0,0 +1,285 @@
+package syslog
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"github.com/confetti-framework/syslog/log_level"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Default bounds of the delay between reconnect attempts.
+const (
+	DefaultReconnectMinBackoff = 100 * time.Millisecond
+	DefaultReconnectMaxBackoff = 30 * time.Second
+)
+
+var errClosed = errors.New("syslog: connection closed")
+
+// Dial connects to the syslog server at raddr on the given network,
+// as accepted by net.Dial, and returns a Writer that sends syslog
+// messages to it. Over stream transports such as tcp every message is
+// prefixed with its length in octets as defined in RFC 6587, over
+// datagram transports such as udp every message is sent in its own
+// datagram without framing.
+//
+// When a write fails, for example because the server restarted, the
+// Writer reconnects and retries the message once before it returns
+// the error. Failed reconnect attempts are spaced by a delay that
+// doubles up to a maximum, see WithReconnectBackoff; writes within
+// that delay fail immediately instead of waiting.
+//
+// Close the Writer to close the connection.
+// The returned Writer is NOT safe for concurrent use by multiple
+// goroutines.
+func Dial(network, raddr string, pri log_level.Priority, hostname, appName, procid string, opts ...Option) (*Writer, error) {
+	dial := func() (net.Conn, error) {
+		return net.Dial(network, raddr)
+	}
+	return dialWriter(dial, !isDatagram(network), pri, hostname, appName, procid, opts)
+}
+
+// DialTLS connects to the syslog server at raddr over TLS as defined in
+// RFC 5425 and returns a Writer that sends syslog messages to it. The
+// messages are framed as for Dial over tcp, and the Writer reconnects
+// in the same way. Certificate verification and the server name are
+// configured by cfg.
+// Close the Writer to close the connection.
+// The returned Writer is NOT safe for concurrent use by multiple
+// goroutines.
+func DialTLS(raddr string, cfg *tls.Config, pri log_level.Priority, hostname, appName, procid string, opts ...Option) (*Writer, error) {
+	dial := func() (net.Conn, error) {
+		conn, err := tls.Dial("tcp", raddr, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("syslog: TLS connection to %s: %w", raddr, err)
+		}
+		return conn, nil
+	}
+	return dialWriter(dial, true, pri, hostname, appName, procid, opts)
+}
+
+func dialWriter(dial func() (net.Conn, error), framed bool, pri log_level.Priority, hostname, appName, procid string, opts []Option) (*Writer, error) {
+	o := newOptions(opts)
+	if err := o.unsupported(dialTarget); err != nil {
+		return nil, err
+	}
+	if err := o.checkPriority(pri); err != nil {
+		return nil, err
+	}
+	t := &transport{
+		dial:        dial,
+		framed:      framed,
+		minBackoff:  o.minBackoff,
+		maxBackoff:  o.maxBackoff,
+		onReconnect: o.onReconnect,
+		batch:       o.batch,
+	}
+	if err := t.connect(); err != nil {
+		return nil, err
+	}
+	return newWriter(t, pri, hostname, appName, procid, o), nil
+}
+
+// localSockets are the conventional paths of the socket of the local
+// syslog daemon: Linux, macOS and the BSDs.
+var localSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// DialLocal connects to the syslog daemon of the local host through
+// the first socket of /dev/log, /var/run/syslog and /var/run/log that
+// accepts a connection, trying a datagram connection before a stream
+// connection, and returns a Writer that sends syslog messages to it.
+// Over a stream connection every message is terminated by a newline.
+// It returns an error when no local socket is available, for example
+// on Windows.
+//
+// The Writer reconnects as described for Dial.
+// Close the Writer to close the connection.
+// The returned Writer is NOT safe for concurrent use by multiple
+// goroutines.
+func DialLocal(pri log_level.Priority, hostname, appName, procid string, opts ...Option) (*Writer, error) {
+	return dialLocal(localSockets, pri, hostname, appName, procid, opts)
+}
+
+func dialLocal(paths []string, pri log_level.Priority, hostname, appName, procid string, opts []Option) (*Writer, error) {
+	dial := func() (net.Conn, error) {
+		for _, path := range paths {
+			for _, network := range []string{"unixgram", "unix"} {
+				if conn, err := net.Dial(network, path); err == nil {
+					return conn, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("syslog: no local syslog socket available at %v", paths)
+	}
+	return dialWriter(dial, false, pri, hostname, appName, procid, opts)
+}
+
+// WithReconnectBackoff sets the bounds of the delay between attempts
+// to reconnect a Writer created by Dial. The delay starts at min and
+// doubles after every failed attempt up to max.
+func WithReconnectBackoff(min, max time.Duration) Option {
+	return func(o *options) {
+		o.minBackoff = min
+		o.maxBackoff = max
+	}
+}
+
+// WithOnReconnect registers a function that is called after a Writer
+// created by Dial reconnected. It receives the error that caused the
+// connection to be replaced.
+func WithOnReconnect(fn func(err error)) Option {
+	return func(o *options) {
+		o.onReconnect = fn
+	}
+}
+
+func isDatagram(network string) bool {
+	switch network {
+	case "udp", "udp4", "udp6", "unixgram":
+		return true
+	}
+	return false
+}
+
+// transport writes messages to a network connection, framing them as
+// required by the type of connection, and reconnects when a write
+// fails. It is safe for concurrent use, so writers that share a
+// transport never open more than one connection.
+type transport struct {
+	mu          sync.Mutex
+	dial        func() (net.Conn, error)
+	conn        net.Conn
+	framed      bool
+	stream      bool
+	closed      bool
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	backoff     time.Duration
+	nextDial    time.Time
+	onReconnect func(error)
+
+	// batch holds the limits of WithBatch, pending the frames that
+	// are not sent yet
+	batch    *batchLimits
+	pending  []byte
+	count    int
+	timer    *time.Timer
+	batchErr error
+}
+
+func (t *transport) connect() error {
+	conn, err := t.dial()
+	if err != nil {
+		return err
+	}
+	t.conn = conn
+	t.stream = !isDatagram(conn.RemoteAddr().Network())
+	return nil
+}
+
+// Write sends a single message. The trailing newline is only sent
+// over stream connections without octet counting, otherwise the octet
+// count or datagram boundary delimits the message. Over a stream
+// connection with WithBatch, the message is added to the batch.
+func (t *transport) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return 0, errClosed
+	}
+	if t.batch != nil && t.stream {
+		return t.addToBatch(p)
+	}
+	if err := t.send(t.frame(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// send writes b to the connection, reconnecting and retrying once
+// when the write fails.
+func (t *transport) send(b []byte) error {
+	err := errClosed
+	if t.conn != nil {
+		if _, err = t.conn.Write(b); err == nil {
+			return nil
+		}
+		t.conn.Close()
+		t.conn = nil
+	}
+
+	if rerr := t.reconnect(err); rerr != nil {
+		return err
+	}
+	if _, err := t.conn.Write(b); err != nil {
+		t.conn.Close()
+		t.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (t *transport) frame(p []byte) []byte {
+	msg := bytes.TrimSuffix(p, nl)
+	if !t.framed {
+		if t.stream {
+			// local stream sockets delimit messages by a newline
+			return append(msg[:len(msg):len(msg)], '\n')
+		}
+		return msg
+	}
+	frame := make([]byte, 0, len(msg)+8)
+	frame = strconv.AppendInt(frame, int64(len(msg)), 10)
+	frame = append(frame, ' ')
+	return append(frame, msg...)
+}
+
+// reconnect replaces the connection that failed because of cause,
+// unless the previous attempt failed too recently.
+func (t *transport) reconnect(cause error) error {
+	now := time.Now()
+	if now.Before(t.nextDial) {
+		return cause
+	}
+	if err := t.connect(); err != nil {
+		t.backoff *= 2
+		if t.backoff < t.minBackoff {
+			t.backoff = t.minBackoff
+		}
+		if t.backoff > t.maxBackoff {
+			t.backoff = t.maxBackoff
+		}
+		t.nextDial = now.Add(t.backoff)
+		return err
+	}
+	t.backoff = 0
+	if t.onReconnect != nil {
+		t.onReconnect(cause)
+	}
+	return nil
+}
+
+// Close sends the pending batch and closes the connection. Writes
+// after Close fail.
+func (t *transport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil
+	}
+	err := t.flushBatch()
+	t.closed = true
+	if t.conn == nil {
+		return err
+	}
+	if cerr := t.conn.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,252 @@
+package syslog_test
+
+import (
+	"bufio"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readOctetCounted reads a single message framed as defined in
+// RFC 6587.
+func readOctetCounted(t *testing.T, r *bufio.Reader) string {
+	length, err := r.ReadString(' ')
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+	if err != nil {
+		t.Fatalf("invalid octet count: %q", length)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		t.Fatal(err)
+	}
+	return string(msg)
+}
+
+func Test_dial_tcp(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	received := make(chan []string)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		received <- []string{readOctetCounted(t, r), readOctetCounted(t, r)}
+	}()
+
+	w, err := syslog.Dial("tcp", ln.Addr().String(), syslog.USER|log_level.NOTICE, "laptop", "testapp", "123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	w.Write([]byte("first\n"))
+	w.Write([]byte("second with\nnewline"))
+
+	msgs := <-received
+	if !strings.HasPrefix(msgs[0], "<13>1 ") || !strings.HasSuffix(msgs[0], " laptop testapp 123 - - first") {
+		t.Fatalf("non-expected message: %q", msgs[0])
+	}
+	if !strings.HasSuffix(msgs[1], " - - second with\nnewline") {
+		t.Fatalf("non-expected message: %q", msgs[1])
+	}
+}
+
+func Test_dial_udp(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	w, err := syslog.Dial("udp", conn.LocalAddr().String(), syslog.USER|log_level.NOTICE, "laptop", "testapp", "123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	w.Write([]byte("message\n"))
+
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := string(buf[:n])
+	if !strings.HasPrefix(msg, "<13>1 ") || !strings.HasSuffix(msg, " laptop testapp 123 - - message") {
+		t.Fatalf("non-expected datagram: %q", msg)
+	}
+}
+
+func Test_dial_reports_write_error(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	w, err := syslog.Dial("tcp", ln.Addr().String(), syslog.USER|log_level.NOTICE, "laptop", "testapp", "123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	if _, err := w.Write([]byte("message")); err == nil {
+		t.Fatal("expected an error writing to a closed connection")
+	}
+}
+
+func Test_dial_reconnects(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	reconnected := make(chan error, 1)
+	w, err := syslog.Dial("tcp", ln.Addr().String(), syslog.USER|log_level.NOTICE, "laptop", "testapp", "123",
+		syslog.WithReconnectBackoff(time.Millisecond, 10*time.Millisecond),
+		syslog.WithOnReconnect(func(err error) {
+			reconnected <- err
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// the collector restarts
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	received := make(chan string)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		received <- readOctetCounted(t, r)
+	}()
+
+	// the first write after the connection was closed by the peer may
+	// succeed, the write after that fails and triggers the reconnect
+	for i := 0; ; i++ {
+		if _, err := w.Write([]byte("message " + strconv.Itoa(i))); err != nil {
+			t.Fatalf("expected the message to be retried, got: %v", err)
+		}
+		select {
+		case cause := <-reconnected:
+			if cause == nil {
+				t.Fatal("expected the cause of the reconnect")
+			}
+			if msg := <-received; !strings.HasSuffix(msg, " - - message "+strconv.Itoa(i)) {
+				t.Fatalf("got: %q, but expected the retried message %d", msg, i)
+			}
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+		if i == 100 {
+			t.Fatal("no reconnect after 100 writes")
+		}
+	}
+}
+
+func Test_dial_local_datagram(t *testing.T) {
+	dir, err := ioutil.TempDir("", "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "log")
+	conn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skipf("unixgram not supported: %v", err)
+	}
+	defer conn.Close()
+
+	w, err := syslog.DialLocalPaths([]string{filepath.Join(dir, "missing"), path}, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	w.Write([]byte("message\n"))
+
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(buf[:n]), " laptop testapp 123 - - message") {
+		t.Fatalf("non-expected datagram: %q", buf[:n])
+	}
+}
+
+func Test_dial_local_stream(t *testing.T) {
+	dir, err := ioutil.TempDir("", "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "log")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("unix sockets not supported: %v", err)
+	}
+	defer ln.Close()
+
+	received := make(chan []string)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		first, _ := r.ReadString('\n')
+		second, _ := r.ReadString('\n')
+		received <- []string{first, second}
+	}()
+
+	w, err := syslog.DialLocalPaths([]string{path}, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	w.Write([]byte("first\n"))
+	w.Write([]byte("second"))
+
+	msgs := <-received
+	if !strings.HasSuffix(msgs[0], " - - first\n") || !strings.HasSuffix(msgs[1], " - - second\n") {
+		t.Fatalf("got messages: %q, but expected newline terminated messages", msgs)
+	}
+}
+
+func Test_dial_local_without_socket(t *testing.T) {
+	_, err := syslog.DialLocalPaths([]string{"/nonexistent/log"}, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123")
+	if err == nil || !strings.Contains(err.Error(), "no local syslog socket available") {
+		t.Fatalf("got error: %v, but expected no local syslog socket", err)
+	}
+}
@@ -0,0 +1,194 @@
+package syslog
+
+import (
+	"context"
+	"errors"
+	"github.com/confetti-framework/syslog/log_level"
+	"time"
+)
+
+// Option configures optional behaviour of the writers and loggers
+// created by this package.
+type Option func(*options)
+
+type options struct {
+	relayID         string
+	writeBuffer     int
+	compactNil      bool
+	before          func(severity log_level.Priority, msgId string)
+	after           func(severity log_level.Priority, n int, err error)
+	clamp           *severityRange
+	frameID         bool
+	numericPriority bool
+	formatter       Formatter
+	severitySD      []severitySD
+	idGenerator     IDGenerator
+	byteLimit       *tokenBucket
+	blockOnLimit    bool
+	minBackoff      time.Duration
+	maxBackoff      time.Duration
+	onReconnect     func(error)
+	slogSDID        string
+	callerDepth     int
+	templates       map[string]string
+	schema          Schema
+	clock           func() time.Time
+	minSeverity     log_level.Priority
+	msgID           string
+	autoDetect      bool
+	appQuota        *appQuota
+	maxSize         int
+	facility        log_level.Priority
+	hostname        string
+	appName         string
+	procid          string
+	compressRotated bool
+	retainFiles     int
+	retainAge       time.Duration
+	promoteCommon   bool
+	nulDelimiter    bool
+	schemaVersion   string
+	utf8BOM         bool
+	extractors      []func(context.Context) StructuredData
+	emitLatency     *emissionLatency
+	timeFormat      string
+	monotonic       *monotonicClock
+	batch           *batchLimits
+	escalation      *escalation
+	utc             bool
+	strictPriority  bool
+	defaultSD       StructuredData
+	order           sdOrder
+
+	// enrichSD is set when the options add structured data to every
+	// message, see structuredData.
+	enrichSD bool
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		minBackoff:  DefaultReconnectMinBackoff,
+		maxBackoff:  DefaultReconnectMaxBackoff,
+		clock:       time.Now,
+		minSeverity: log_level.DEBUG,
+		utf8BOM:     DefaultUTF8BOM,
+		facility:    USER,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.formatter == nil {
+		o.formatter = rfc5424Formatter{compact: o.compactNil, bom: o.utf8BOM, timeFormat: o.timeFormat, order: o.order}
+	}
+	o.enrichSD = o.relayID != "" || o.frameID || o.numericPriority || len(o.severitySD) > 0 || o.callerDepth > 0 ||
+		o.schemaVersion != "" || o.byteLimit != nil || o.promoteCommon || o.emitLatency != nil || o.defaultSD != nil
+	return o
+}
+
+// target is the kind of writer or logger that is configured by
+// options, some options only apply to one kind.
+type target int
+
+const (
+	// messageTarget are NewWriter and the loggers.
+	messageTarget target = iota
+	// dialTarget are Dial, DialTLS and DialLocal.
+	dialTarget
+	// rotatingTarget is NewRotatingFileWriter.
+	rotatingTarget
+)
+
+// unsupported returns an error for an option that doesn't apply to t,
+// so it isn't silently ignored.
+func (o *options) unsupported(t target) error {
+	if o.batch != nil && t != dialTarget {
+		return errors.New("syslog: WithBatch only applies to Dial, DialTLS and DialLocal")
+	}
+	if (o.compressRotated || o.retainFiles > 0 || o.retainAge > 0) && t != rotatingTarget {
+		return errors.New("syslog: WithCompressRotated and WithRetention only apply to NewRotatingFileWriter")
+	}
+	return nil
+}
+
+// WithClock sets the function that provides the timestamp of every
+// message, it defaults to time.Now. A fixed clock makes the output
+// deterministic in tests:
+//
+//	syslog.WithClock(func() time.Time { return fixed })
+func WithClock(clock func() time.Time) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithMsgID sets the MSGID of the messages generated by a Writer,
+// which otherwise have none. Loggers use it for messages that are
+// logged with an empty msgId.
+func WithMsgID(msgID string) Option {
+	return func(o *options) {
+		o.msgID = msgID
+	}
+}
+
+// structuredData returns sd extended with the structured data
+// configured by the options for a message with the given priority
+// and timestamp. sd itself is never modified.
+func (o *options) structuredData(sd StructuredData, pri log_level.Priority, timestamp time.Time) StructuredData {
+	if !o.enrichSD {
+		return sd
+	}
+	sd = sd.Clone()
+	if o.promoteCommon {
+		promoteCommonParams(sd)
+	}
+	merge(sd, o.defaultSD)
+	for _, s := range o.severitySD {
+		if pri.Severity() <= s.threshold {
+			merge(sd, s.sd)
+		}
+	}
+	if o.relayID != "" {
+		appendRelayID(sd, o.relayID)
+	}
+	if o.frameID {
+		sd.Element(metaElement).Set("frameId", o.frameIDFor(timestamp))
+	}
+	if o.numericPriority {
+		setNumericPriority(sd, pri)
+	}
+	if o.callerDepth > 0 {
+		sd.Element("caller").Set("stack", callerStack(o.callerDepth))
+	}
+	if o.schemaVersion != "" {
+		sd.Element("schema").Set("version", o.schemaVersion)
+	}
+	if o.byteLimit != nil {
+		o.setSuppressed(sd)
+	}
+	o.setEmissionLatency(sd)
+	return sd
+}
+
+// reframe applies the options to a message that was already
+// formatted, for example by another writer that forwards to this one.
+func (o *options) reframe(frame []byte) []byte {
+	if o.relayID == "" {
+		return frame
+	}
+	start, end, ok := sdBounds(frame)
+	if !ok {
+		return frame
+	}
+	sd, _, err := parseStructuredData(frame[start:end])
+	if err != nil {
+		return frame
+	}
+	sd = sd.Clone()
+	appendRelayID(sd, o.relayID)
+
+	out := make([]byte, 0, len(frame)+32)
+	out = append(out, frame[:start]...)
+	out = append(out, sd.String()...)
+	out = append(out, frame[end:]...)
+	return out
+}
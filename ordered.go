@@ -0,0 +1,68 @@
+package syslog
+
+import "sort"
+
+// WithParamOrder makes a Writer or Logger write the params of the
+// element with the given id in the given order, instead of in
+// lexicographical order:
+//
+//	syslog.WithParamOrder("id1", "seq", "ts")
+//
+// writes [id1 seq="1" ts="..."] for collectors that key on the
+// position of a param. Params that aren't listed follow in
+// lexicographical order, listed params that the element doesn't hold
+// are skipped. The order is kept by the writer, the SDElement itself
+// holds nothing but its params. It applies to the RFC 5424 format.
+func WithParamOrder(id string, names ...string) Option {
+	return func(o *options) {
+		if o.order.params == nil {
+			o.order.params = map[string][]string{}
+		}
+		o.order.params[id] = append([]string(nil), names...)
+	}
+}
+
+// sdOrder is the order in which the elements and params of structured
+// data are written. The zero value writes both in lexicographical
+// order.
+type sdOrder struct {
+	less   func(a, b string) bool
+	params map[string][]string
+}
+
+// ids returns the ids of the elements of d that hold params in the
+// configured order.
+func (o sdOrder) ids(d StructuredData) []string {
+	ids := d.Ids()
+	if o.less != nil {
+		sort.SliceStable(ids, func(i, j int) bool {
+			return o.less(ids[i], ids[j])
+		})
+	}
+	return ids
+}
+
+// names returns the param names of the element with the given id in
+// the configured order.
+func (o sdOrder) names(id string, elem SDElement) []string {
+	order, ok := o.params[id]
+	if !ok {
+		return elem.Names()
+	}
+	names := make([]string, 0, len(elem))
+	seen := make(map[string]bool, len(order))
+	for _, name := range order {
+		if _, ok := elem[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+	var rest []string
+	for name := range elem {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
@@ -0,0 +1,47 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+func Test_param_order(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithParamOrder("id1", "seq", "ts", "missing"),
+	)
+
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("alpha", "a").Set("ts", "2021").Set("seq", "1").Set("beta", "b")
+	sd.Element("id0").Set("b", "2").Set("a", "1")
+	l.Log(log_level.INFO, "", sd, "message")
+
+	expected := ` [id0 a="1" b="2"][id1 seq="1" ts="2021" alpha="a" beta="b"] message`
+	if !strings.Contains(buf.String(), expected) {
+		t.Fatalf("got output: %s, but expected it to contain: %s", buf.String(), expected)
+	}
+}
+
+func Test_param_order_keeps_element_a_plain_map(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithParamOrder("id1", "ts"),
+	)
+
+	sd := syslog.StructuredData{}
+	elem := sd.Element("id1").Set("ts", "2021").Set("alpha", "a")
+	l.Log(log_level.INFO, "", sd, "message")
+
+	if !strings.Contains(buf.String(), ` [id1 ts="2021" alpha="a"] message`) {
+		t.Fatalf("non-expected output: %s", buf.String())
+	}
+	if len(elem) != 2 {
+		t.Fatalf("got element: %v, but expected only its 2 params", elem)
+	}
+	if sd.String() != `[id1 alpha="a" ts="2021"]` {
+		t.Fatalf("got string: %v, but expected lexicographical order", sd.String())
+	}
+}
@@ -0,0 +1,247 @@
+package syslog
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/confetti-framework/syslog/log_level"
+	"strconv"
+	"time"
+)
+
+// Message is a syslog message as defined in RFC 5424. Header fields
+// that hold the NILVALUE are empty, the Timestamp is zero and the
+// StructuredData nil.
+type Message struct {
+	Priority       log_level.Priority
+	Version        int
+	Timestamp      time.Time
+	Hostname       string
+	AppName        string
+	ProcID         string
+	MsgID          string
+	StructuredData StructuredData
+	Msg            []byte
+}
+
+// Severity returns the severity of the message.
+func (m *Message) Severity() log_level.Priority {
+	return m.Priority.Severity()
+}
+
+// Facility returns the facility of the message.
+func (m *Message) Facility() log_level.Priority {
+	return m.Priority.Facility()
+}
+
+// maxPri is the largest PRI value: facility LOCAL7 with severity DEBUG.
+const maxPri = 191
+
+// Parse parses a syslog message as defined in RFC 5424. A single
+// trailing newline and the UTF-8 BOM at the start of the MSG are not
+// considered part of the message.
+func Parse(d []byte) (*Message, error) {
+	m := &Message{}
+
+	if len(d) == 0 || d[0] != '<' {
+		return nil, fmt.Errorf("syslog: message does not start with '<'")
+	}
+	end := bytes.IndexByte(d, '>')
+	if end < 2 || end > 4 {
+		return nil, fmt.Errorf("syslog: invalid PRI")
+	}
+	pri, err := parseDigits(d[1:end])
+	if err != nil || pri > maxPri {
+		return nil, fmt.Errorf("syslog: invalid PRI %q", d[1:end])
+	}
+	m.Priority = log_level.Priority(pri)
+	d = d[end+1:]
+
+	field, d, err := nextField(d, "VERSION")
+	if err != nil {
+		return nil, err
+	}
+	if m.Version, err = parseDigits(field); err != nil || m.Version == 0 {
+		return nil, fmt.Errorf("syslog: invalid VERSION %q", field)
+	}
+
+	if field, d, err = nextField(d, "TIMESTAMP"); err != nil {
+		return nil, err
+	}
+	if string(field) != "-" {
+		if m.Timestamp, err = time.Parse(time.RFC3339Nano, string(field)); err != nil {
+			return nil, fmt.Errorf("syslog: invalid TIMESTAMP %q", field)
+		}
+	}
+
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{
+		{"HOSTNAME", &m.Hostname},
+		{"APP-NAME", &m.AppName},
+		{"PROCID", &m.ProcID},
+		{"MSGID", &m.MsgID},
+	} {
+		if field, d, err = nextField(d, f.name); err != nil {
+			return nil, err
+		}
+		if string(field) != "-" {
+			*f.value = string(field)
+		}
+	}
+
+	sd, n, err := parseStructuredData(d)
+	if err != nil {
+		return nil, err
+	}
+	m.StructuredData = sd
+	d = d[n:]
+
+	if len(d) > 0 && d[len(d)-1] == '\n' {
+		d = d[:len(d)-1]
+	}
+	if len(d) > 0 {
+		if d[0] != ' ' {
+			return nil, fmt.Errorf("syslog: missing space after STRUCTURED-DATA")
+		}
+		m.Msg = append([]byte(nil), bytes.TrimPrefix(d[1:], []byte(utf8BOM))...)
+	}
+	return m, nil
+}
+
+// nextField returns the space terminated header field at the start of
+// d and the remainder of d after the space.
+func nextField(d []byte, name string) ([]byte, []byte, error) {
+	i := bytes.IndexByte(d, ' ')
+	if i < 0 {
+		return nil, nil, fmt.Errorf("syslog: missing %s", name)
+	}
+	if i == 0 {
+		return nil, nil, fmt.Errorf("syslog: empty %s", name)
+	}
+	return d[:i], d[i+1:], nil
+}
+
+func parseDigits(d []byte) (int, error) {
+	for _, c := range d {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("syslog: invalid digit %q", c)
+		}
+	}
+	return strconv.Atoi(string(d))
+}
+
+// hasPRI reports whether d starts with a valid PRI, such as <13>, as
+// a message that is already formatted does.
+func hasPRI(d []byte) bool {
+	_, ok := framePRI(d)
+	return ok
+}
+
+// framePRI returns the PRI that d starts with, if it is valid.
+func framePRI(d []byte) (log_level.Priority, bool) {
+	if len(d) < 3 || d[0] != '<' {
+		return 0, false
+	}
+	end := bytes.IndexByte(d, '>')
+	if end < 2 || end > 4 {
+		return 0, false
+	}
+	pri, err := parseDigits(d[1:end])
+	if err != nil || pri > maxPri {
+		return 0, false
+	}
+	return log_level.Priority(pri), true
+}
+
+// headerFields is the number of space terminated fields that precede
+// the structured data in an RFC 5424 message: PRI and VERSION,
+// TIMESTAMP, HOSTNAME, APP-NAME, PROCID and MSGID.
+const headerFields = 6
+
+// sdBounds returns the offsets of the STRUCTURED-DATA field in an
+// RFC 5424 formatted frame.
+func sdBounds(frame []byte) (start, end int, ok bool) {
+	for i := 0; i < headerFields; i++ {
+		n := bytes.IndexByte(frame[start:], ' ')
+		if n < 0 {
+			return 0, 0, false
+		}
+		start += n + 1
+	}
+	_, n, err := parseStructuredData(frame[start:])
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, start + n, true
+}
+
+// parseStructuredData parses the STRUCTURED-DATA field at the start
+// of b. It returns the parsed data, which is nil for the NILVALUE, and
+// the number of bytes consumed.
+func parseStructuredData(b []byte) (StructuredData, int, error) {
+	if len(b) == 0 {
+		return nil, 0, fmt.Errorf("syslog: missing structured data")
+	}
+	if b[0] == '-' {
+		return nil, 1, nil
+	}
+
+	sd := StructuredData{}
+	i := 0
+	for i < len(b) && b[i] == '[' {
+		i++
+		idEnd := i
+		for idEnd < len(b) && b[idEnd] != ' ' && b[idEnd] != ']' {
+			idEnd++
+		}
+		if idEnd == i {
+			return nil, 0, fmt.Errorf("syslog: empty SD-ID at offset %d", i)
+		}
+		id := string(b[i:idEnd])
+		elem := sd.Element(id)
+		i = idEnd
+
+		for i < len(b) && b[i] == ' ' {
+			i++
+			eq := bytes.IndexByte(b[i:], '=')
+			if eq <= 0 {
+				return nil, 0, fmt.Errorf("syslog: invalid SD-PARAM at offset %d", i)
+			}
+			name := string(b[i : i+eq])
+			i += eq + 1
+			if i >= len(b) || b[i] != '"' {
+				return nil, 0, fmt.Errorf("syslog: unquoted value for SD-PARAM %q", name)
+			}
+			i++
+
+			value := &bytes.Buffer{}
+			for {
+				if i >= len(b) {
+					return nil, 0, fmt.Errorf("syslog: unterminated value for SD-PARAM %q", name)
+				}
+				c := b[i]
+				if c == '"' {
+					i++
+					break
+				}
+				if c == '\\' && i+1 < len(b) && (b[i+1] == '"' || b[i+1] == '\\' || b[i+1] == ']') {
+					c = b[i+1]
+					i++
+				}
+				value.WriteByte(c)
+				i++
+			}
+			elem.Set(name, value.String())
+		}
+
+		if i >= len(b) || b[i] != ']' {
+			return nil, 0, fmt.Errorf("syslog: unterminated SD-ELEMENT %q", id)
+		}
+		i++
+	}
+	if i == 0 {
+		return nil, 0, fmt.Errorf("syslog: invalid structured data")
+	}
+	return sd, i, nil
+}
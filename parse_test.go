@@ -0,0 +1,76 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_parse_round_trip(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", `quote " backslash \ bracket ]`)
+	sd.Element("id2").Set("par1", "val1").Set("par2", "val2")
+
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.LOCAL0, "hostname", "appName", "procid")
+	before := time.Now().Truncate(time.Millisecond)
+	l.Log(log_level.ERROR, "msgid", sd, "login failed")
+	after := time.Now()
+
+	m, err := syslog.Parse(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Timestamp.Before(before) || m.Timestamp.After(after) {
+		t.Fatalf("got timestamp: %v, but expected it between %v and %v", m.Timestamp, before, after)
+	}
+	m.Timestamp = time.Time{}
+
+	expected := &syslog.Message{
+		Priority:       syslog.LOCAL0 | log_level.ERROR,
+		Version:        1,
+		Hostname:       "hostname",
+		AppName:        "appName",
+		ProcID:         "procid",
+		MsgID:          "msgid",
+		StructuredData: sd,
+		Msg:            []byte("login failed"),
+	}
+	if !reflect.DeepEqual(m, expected) {
+		t.Fatalf("got message: %#v, but expected: %#v", m, expected)
+	}
+}
+
+func Test_parse_nil_values(t *testing.T) {
+	m, err := syslog.Parse([]byte("<13>1 - - - - - -\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &syslog.Message{Priority: 13, Version: 1}
+	if !reflect.DeepEqual(m, expected) {
+		t.Fatalf("got message: %#v, but expected: %#v", m, expected)
+	}
+}
+
+func Test_parse_malformed(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"13>1 - - - - - - message",
+		"<1a>1 - - - - - - message",
+		"<192>1 - - - - - - message",
+		"<13>1 yesterday - - - - - message",
+		"<13>1 - - - -",
+		`<13>1 - - - - - [id1 par1="val1" message`,
+		`<13>1 - - - - - [id1 par1="val1] message`,
+		`<13>1 - - - - - [id1 par1=val1] message`,
+	} {
+		if _, err := syslog.Parse([]byte(input)); err == nil {
+			t.Fatalf("expected an error for: %q", input)
+		}
+	}
+}
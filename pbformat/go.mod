@@ -0,0 +1,10 @@
+module github.com/confetti-framework/syslog/pbformat
+
+go 1.23
+
+require (
+	github.com/confetti-framework/syslog v0.0.0
+	google.golang.org/protobuf v1.36.10
+)
+
+replace github.com/confetti-framework/syslog => ../
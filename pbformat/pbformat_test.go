@@ -0,0 +1,94 @@
+package pbformat_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/confetti-framework/syslog/pbformat"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+func Test_proto_formatter_round_trip(t *testing.T) {
+	timestamp := time.Date(2021, 3, 4, 5, 6, 7, 8, time.UTC)
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "laptop", "testapp", "123",
+		syslog.WithFormatter(pbformat.NewProtoFormatter()),
+		syslog.WithClock(func() time.Time { return timestamp }),
+	)
+
+	sd := syslog.StructuredData{}
+	sd.Element("request@32473").Set("status", "200").Set("path", "/users")
+	l.Log(log_level.ERROR, "LoginFailed", sd, "login failed")
+	l.Log(log_level.INFO, "", nil, "second")
+
+	r := bufio.NewReader(buf)
+	record, err := pbformat.ReadRecord(r)
+	if err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+	expected := &pbformat.Record{
+		Priority:  uint32(syslog.USER | log_level.ERROR),
+		Timestamp: timestamp.UnixNano(),
+		Hostname:  "laptop",
+		AppName:   "testapp",
+		Procid:    "123",
+		Msgid:     "LoginFailed",
+		StructuredData: []*pbformat.SDElement{{
+			Id: "request@32473",
+			Params: []*pbformat.SDParam{
+				{Name: "path", Value: "/users"},
+				{Name: "status", Value: "200"},
+			},
+		}},
+		Msg: []byte("login failed"),
+	}
+	if !proto.Equal(record, expected) {
+		t.Fatalf("got record: %+v, but expected: %+v", record, expected)
+	}
+
+	record, err = pbformat.ReadRecord(r)
+	if err != nil || string(record.Msg) != "second" || record.Msgid != "" || record.StructuredData != nil {
+		t.Fatalf("got record: %+v and error: %v, but expected the second message", record, err)
+	}
+	if r.Buffered() != 0 {
+		t.Fatalf("got %d trailing bytes, but expected none", r.Buffered())
+	}
+}
+
+func Test_record_wire_format(t *testing.T) {
+	r := &pbformat.Record{Priority: 11, Hostname: "h"}
+
+	// field 1 varint 11, field 3 length 1 "h"
+	expected := []byte{0x08, 0x0b, 0x1a, 0x01, 'h'}
+	b, err := proto.Marshal(r)
+	if err != nil || !bytes.Equal(b, expected) {
+		t.Fatalf("got bytes: %x and error: %v, but expected: %x", b, err, expected)
+	}
+}
+
+func Test_proto_formatter_unmarshals_with_proto(t *testing.T) {
+	timestamp := time.Date(2021, 3, 4, 5, 6, 7, 8, time.UTC)
+	sd := syslog.StructuredData{}
+	sd.Element("request@32473").Set("path", "/users\xff")
+
+	frame := pbformat.NewProtoFormatter().Format(syslog.USER|log_level.ERROR, timestamp, "laptop", "testapp", "123", "", sd, []byte("\xffbinary"))
+	size, n := binary.Uvarint(frame)
+	if n <= 0 || int(size) != len(frame)-n {
+		t.Fatalf("got length prefix: %d, but expected: %d", size, len(frame)-n)
+	}
+
+	record := &pbformat.Record{}
+	if err := proto.Unmarshal(frame[n:], record); err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+	if record.GetHostname() != "laptop" || record.GetTimestamp() != timestamp.UnixNano() || string(record.GetMsg()) != "\xffbinary" {
+		t.Fatalf("non-expected record: %v", record)
+	}
+	if value := record.GetStructuredData()[0].GetParams()[0].GetValue(); value != "/users\uFFFD" {
+		t.Fatalf("got value: %q, but expected the invalid byte to be replaced", value)
+	}
+}
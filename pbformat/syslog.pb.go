@@ -0,0 +1,301 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: syslog.proto
+
+package pbformat
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Record holds the fields of a syslog message.
+type Record struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Priority uint32                 `protobuf:"varint,1,opt,name=priority,proto3" json:"priority,omitempty"`
+	// timestamp in nanoseconds since the Unix epoch
+	Timestamp      int64        `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Hostname       string       `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	AppName        string       `protobuf:"bytes,4,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	Procid         string       `protobuf:"bytes,5,opt,name=procid,proto3" json:"procid,omitempty"`
+	Msgid          string       `protobuf:"bytes,6,opt,name=msgid,proto3" json:"msgid,omitempty"`
+	StructuredData []*SDElement `protobuf:"bytes,7,rep,name=structured_data,json=structuredData,proto3" json:"structured_data,omitempty"`
+	Msg            []byte       `protobuf:"bytes,8,opt,name=msg,proto3" json:"msg,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	mi := &file_syslog_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_syslog_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_syslog_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Record) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Record) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Record) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *Record) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+func (x *Record) GetProcid() string {
+	if x != nil {
+		return x.Procid
+	}
+	return ""
+}
+
+func (x *Record) GetMsgid() string {
+	if x != nil {
+		return x.Msgid
+	}
+	return ""
+}
+
+func (x *Record) GetStructuredData() []*SDElement {
+	if x != nil {
+		return x.StructuredData
+	}
+	return nil
+}
+
+func (x *Record) GetMsg() []byte {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+type SDElement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Params        []*SDParam             `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SDElement) Reset() {
+	*x = SDElement{}
+	mi := &file_syslog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SDElement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SDElement) ProtoMessage() {}
+
+func (x *SDElement) ProtoReflect() protoreflect.Message {
+	mi := &file_syslog_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SDElement.ProtoReflect.Descriptor instead.
+func (*SDElement) Descriptor() ([]byte, []int) {
+	return file_syslog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SDElement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SDElement) GetParams() []*SDParam {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type SDParam struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SDParam) Reset() {
+	*x = SDParam{}
+	mi := &file_syslog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SDParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SDParam) ProtoMessage() {}
+
+func (x *SDParam) ProtoReflect() protoreflect.Message {
+	mi := &file_syslog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SDParam.ProtoReflect.Descriptor instead.
+func (*SDParam) Descriptor() ([]byte, []int) {
+	return file_syslog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SDParam) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SDParam) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_syslog_proto protoreflect.FileDescriptor
+
+const file_syslog_proto_rawDesc = "" +
+	"\n" +
+	"\fsyslog.proto\x12\x0fconfetti.syslog\"\xfe\x01\n" +
+	"\x06Record\x12\x1a\n" +
+	"\bpriority\x18\x01 \x01(\rR\bpriority\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\bhostname\x18\x03 \x01(\tR\bhostname\x12\x19\n" +
+	"\bapp_name\x18\x04 \x01(\tR\aappName\x12\x16\n" +
+	"\x06procid\x18\x05 \x01(\tR\x06procid\x12\x14\n" +
+	"\x05msgid\x18\x06 \x01(\tR\x05msgid\x12C\n" +
+	"\x0fstructured_data\x18\a \x03(\v2\x1a.confetti.syslog.SDElementR\x0estructuredData\x12\x10\n" +
+	"\x03msg\x18\b \x01(\fR\x03msg\"M\n" +
+	"\tSDElement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x120\n" +
+	"\x06params\x18\x02 \x03(\v2\x18.confetti.syslog.SDParamR\x06params\"3\n" +
+	"\aSDParam\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05valueB/Z-github.com/confetti-framework/syslog/pbformatb\x06proto3"
+
+var (
+	file_syslog_proto_rawDescOnce sync.Once
+	file_syslog_proto_rawDescData []byte
+)
+
+func file_syslog_proto_rawDescGZIP() []byte {
+	file_syslog_proto_rawDescOnce.Do(func() {
+		file_syslog_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_syslog_proto_rawDesc), len(file_syslog_proto_rawDesc)))
+	})
+	return file_syslog_proto_rawDescData
+}
+
+var file_syslog_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_syslog_proto_goTypes = []any{
+	(*Record)(nil),    // 0: confetti.syslog.Record
+	(*SDElement)(nil), // 1: confetti.syslog.SDElement
+	(*SDParam)(nil),   // 2: confetti.syslog.SDParam
+}
+var file_syslog_proto_depIdxs = []int32{
+	1, // 0: confetti.syslog.Record.structured_data:type_name -> confetti.syslog.SDElement
+	2, // 1: confetti.syslog.SDElement.params:type_name -> confetti.syslog.SDParam
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_syslog_proto_init() }
+func file_syslog_proto_init() {
+	if File_syslog_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_syslog_proto_rawDesc), len(file_syslog_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_syslog_proto_goTypes,
+		DependencyIndexes: file_syslog_proto_depIdxs,
+		MessageInfos:      file_syslog_proto_msgTypes,
+	}.Build()
+	File_syslog_proto = out.File
+	file_syslog_proto_goTypes = nil
+	file_syslog_proto_depIdxs = nil
+}
@@ -0,0 +1,73 @@
+package syslog
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog/log_level"
+	"sync"
+	"time"
+)
+
+// maxPooledBuffer is the capacity above which a buffer is not returned
+// to the pool, so a single large message does not keep its memory
+// alive.
+const maxPooledBuffer = 64 * 1024
+
+// bufferPool holds the buffers that messages are formatted in by
+// writers and loggers.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return &bytes.Buffer{}
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufferPool.Put(buf)
+	}
+}
+
+// bufferFormatter is implemented by the formatters of this package
+// that can format a message into a given buffer instead of allocating
+// a new one.
+type bufferFormatter interface {
+	formatTo(
+		buf *bytes.Buffer,
+		pri log_level.Priority,
+		timestamp time.Time,
+		hostname string,
+		appName string,
+		procid string,
+		msgid string,
+		sd StructuredData,
+		msg []byte,
+	)
+}
+
+// formatInto formats a message with f. When f is a bufferFormatter
+// and buf is not nil, the message is formatted into buf and the
+// returned frame is only valid until buf is reused.
+func formatInto(
+	buf *bytes.Buffer,
+	f Formatter,
+	pri log_level.Priority,
+	timestamp time.Time,
+	hostname string,
+	appName string,
+	procid string,
+	msgid string,
+	sd StructuredData,
+	msg []byte,
+) []byte {
+	if bf, ok := f.(bufferFormatter); ok && buf != nil {
+		buf.Reset()
+		bf.formatTo(buf, pri, timestamp, hostname, appName, procid, msgid, sd, msg)
+		return buf.Bytes()
+	}
+	return f.Format(pri, timestamp, hostname, appName, procid, msgid, sd, msg)
+}
@@ -0,0 +1,62 @@
+package syslog_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordingWriter struct {
+	frames []string
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.frames = append(w.frames, string(p))
+	return len(p), nil
+}
+
+func Test_pooled_buffers_are_not_shared(t *testing.T) {
+	out := &recordingWriter{}
+	l := syslog.NewTeeFormatterLogger(syslog.NewRFC5424Formatter(), out, syslog.NewRFC5424Formatter(), out,
+		syslog.USER, "hostname", "appName", "procid")
+	for i := 0; i < 100; i++ {
+		l.Log(log_level.INFO, "", nil, "message %d %s", i, strings.Repeat("x", i*10))
+	}
+
+	for i, frame := range out.frames {
+		expected := fmt.Sprintf("message %d %s\n", i/2, strings.Repeat("x", i/2*10))
+		if !strings.HasSuffix(frame, expected) {
+			t.Fatalf("got frame %d: %q, but expected suffix: %q", i, frame, expected)
+		}
+	}
+}
+
+func Test_formatter_result_is_not_reused(t *testing.T) {
+	f := syslog.NewRFC5424Formatter()
+	ts := time.Date(2017, 8, 15, 23, 13, 15, 335e6, time.UTC)
+	first := f.Format(syslog.USER|log_level.INFO, ts, "hostname", "appName", "procid", "", nil, []byte("first"))
+	expected := string(first)
+
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid")
+	l.Log(log_level.INFO, "", nil, "second")
+
+	if string(first) != expected {
+		t.Fatalf("got: %q, but expected: %q", first, expected)
+	}
+}
+
+func Benchmark_logger_steady_state(b *testing.B) {
+	l := syslog.NewLogger(ioutil.Discard, syslog.USER, "hostname", "appName", "procid")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Log(log_level.INFO, "msgid", nil, "message")
+	}
+}
@@ -0,0 +1,56 @@
+package syslog
+
+// commonElement holds the params promoted by WithPromoteCommonParams.
+const commonElement = "common"
+
+// WithPromoteCommonParams moves the params that every element of the
+// structured data of a message has, with the same value, to a single
+// common element. For example
+//
+//	[http request_id="42" status="200"][db request_id="42" rows="3"]
+//
+// is written as
+//
+//	[common request_id="42"][db rows="3"][http status="200"]
+//
+// Only the structured data passed to Log is considered, not the
+// elements added by other options, and only when it has at least two
+// elements. Elements that are left without params are omitted.
+func WithPromoteCommonParams() Option {
+	return func(o *options) {
+		o.promoteCommon = true
+	}
+}
+
+// promoteCommonParams moves the params shared by all elements of sd,
+// except the common element itself, to the common element.
+func promoteCommonParams(sd StructuredData) {
+	var ids []string
+	for _, id := range sd.Ids() {
+		if id != commonElement {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < 2 {
+		return
+	}
+
+	first := sd[ids[0]]
+	for _, name := range first.Names() {
+		value := first[name]
+		shared := true
+		for _, id := range ids[1:] {
+			if v, ok := sd[id][name]; !ok || v != value {
+				shared = false
+				break
+			}
+		}
+		if !shared {
+			continue
+		}
+		sd.Element(commonElement).Set(name, value)
+		for _, id := range ids {
+			delete(sd[id], name)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+func Test_promote_common_params(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithPromoteCommonParams())
+
+	sd := syslog.StructuredData{}
+	sd.Element("http").Set("request_id", "42").Set("status", "200").Set("tenant", "a")
+	sd.Element("db").Set("request_id", "42").Set("rows", "3").Set("tenant", "b")
+	sd.Element("cache").Set("request_id", "42")
+	l.Log(log_level.INFO, "", sd, "request handled")
+
+	expected := ` [common request_id="42"][db rows="3" tenant="b"][http status="200" tenant="a"] request handled`
+	if !strings.HasSuffix(buf.String(), expected+"\n") {
+		t.Fatalf("got: %q, but expected suffix: %q", buf.String(), expected)
+	}
+	if sd.Element("http").Get("request_id") != "42" {
+		t.Fatalf("expected the structured data of the caller to be left untouched")
+	}
+}
+
+func Test_promote_common_params_single_element(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithPromoteCommonParams())
+
+	sd := syslog.StructuredData{}
+	sd.Element("http").Set("request_id", "42")
+	l.Log(log_level.INFO, "", sd, "request handled")
+
+	if !strings.HasSuffix(buf.String(), ` [http request_id="42"] request handled`+"\n") {
+		t.Fatalf("non-expected output: %q", buf.String())
+	}
+}
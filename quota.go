@@ -0,0 +1,76 @@
+package syslog
+
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+// WithPerAppQuota limits the number of messages per APP-NAME to
+// perInterval in every interval, so one application sharing a writer
+// can't starve the others. Messages over the quota are dropped, the
+// after hook receives ErrDropped for them.
+//
+// A Writer counts the messages it formats by its own app name and
+// messages that are already formatted by the APP-NAME in their
+// header.
+func WithPerAppQuota(perInterval int, interval time.Duration) Option {
+	return func(o *options) {
+		o.appQuota = &appQuota{
+			limit:    perInterval,
+			interval: interval,
+			counts:   map[string]int{},
+		}
+	}
+}
+
+// appQuota counts the messages per app name in fixed windows of
+// interval.
+type appQuota struct {
+	mu          sync.Mutex
+	limit       int
+	interval    time.Duration
+	windowStart time.Time
+	counts      map[string]int
+}
+
+func (q *appQuota) allow(appName string, now time.Time) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if now.Sub(q.windowStart) >= q.interval {
+		q.windowStart = now
+		q.counts = map[string]int{}
+	}
+	if q.counts[appName] >= q.limit {
+		return false
+	}
+	q.counts[appName]++
+	return true
+}
+
+// allowApp returns ErrDropped when a message of appName exceeds the
+// quota set by WithPerAppQuota.
+func (o *options) allowApp(appName string) error {
+	if o.appQuota == nil || o.appQuota.allow(appName, o.clock()) {
+		return nil
+	}
+	return ErrDropped
+}
+
+// frameAppName returns the APP-NAME of an RFC 5424 formatted frame,
+// "-" for the NILVALUE.
+func frameAppName(frame []byte) string {
+	// skip PRI and VERSION, TIMESTAMP and HOSTNAME
+	for i := 0; i < 3; i++ {
+		n := bytes.IndexByte(frame, ' ')
+		if n < 0 {
+			return ""
+		}
+		frame = frame[n+1:]
+	}
+	if n := bytes.IndexByte(frame, ' '); n >= 0 {
+		frame = frame[:n]
+	}
+	return string(frame)
+}
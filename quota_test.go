@@ -0,0 +1,52 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_per_app_quota(t *testing.T) {
+	var dropped []string
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "relay", "relay", "", syslog.WithPerAppQuota(2, time.Hour),
+		syslog.WithHooks(nil, func(severity log_level.Priority, n int, err error) {
+			if err == syslog.ErrDropped {
+				dropped = append(dropped, "dropped")
+			}
+		}),
+	)
+
+	for i := 0; i < 3; i++ {
+		w.Write([]byte("<13>1 2021-03-04T05:06:07.000Z host tenantA - - - message a\n"))
+		w.Write([]byte("<13>1 2021-03-04T05:06:07.000Z host tenantB - - - message b\n"))
+	}
+
+	if strings.Count(buf.String(), "tenantA") != 2 || strings.Count(buf.String(), "tenantB") != 2 {
+		t.Fatalf("got output: %s, but expected 2 messages per app", buf.String())
+	}
+	if len(dropped) != 2 {
+		t.Fatalf("got %d dropped messages, but expected: 2", len(dropped))
+	}
+}
+
+func Test_per_app_quota_resets_after_interval(t *testing.T) {
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithPerAppQuota(1, time.Minute),
+		syslog.WithClock(func() time.Time { return now }),
+	)
+
+	l.Log(log_level.INFO, "", nil, "first")
+	l.Log(log_level.INFO, "", nil, "dropped")
+	now = now.Add(time.Minute)
+	l.Log(log_level.INFO, "", nil, "second")
+
+	if strings.Contains(buf.String(), "dropped") || !strings.Contains(buf.String(), "first") || !strings.Contains(buf.String(), "second") {
+		t.Fatalf("non-expected output: %s", buf.String())
+	}
+}
@@ -0,0 +1,63 @@
+package syslog
+
+import (
+	"fmt"
+	"github.com/confetti-framework/syslog/log_level"
+	"strconv"
+	"strings"
+)
+
+// NewRateLimiter returns a Logger that passes at most perSecond
+// messages per second of every severity to l, with bursts of up to
+// burst messages. Every severity has a limit of its own, so a flood of
+// DEBUG messages doesn't suppress CRITICAL messages. Messages over the
+// limit are dropped.
+//
+// Before the first message of a severity that passes after messages
+// of that severity were dropped, a NOTICE summary is logged:
+//
+//	[throttle severity="err" suppressed="12"] ERR suppressed 12 messages
+//
+// When the summary fails to be logged, Log returns its error, unless
+// the message itself fails as well, and the dropped messages are
+// summarized again before the next message.
+//
+// The returned Logger is safe for concurrent use by multiple
+// goroutines.
+func NewRateLimiter(l Logger, perSecond float64, burst int) Logger {
+	r := &rateLimiter{l: l}
+	for i := range r.buckets {
+		r.buckets[i] = newTokenBucket(perSecond, float64(burst))
+	}
+	return r
+}
+
+type rateLimiter struct {
+	l       Logger
+	buckets [log_level.DEBUG + 1]*tokenBucket
+}
+
+func (r *rateLimiter) Log(severity log_level.Priority, msgId string, sd StructuredData, msgFormat string, a ...interface{}) error {
+	ok, suppressed := r.buckets[severity.Severity()].takeSuppressed(1)
+	if !ok {
+		return nil
+	}
+	var summaryErr error
+	if suppressed > 0 {
+		key := KeyBySeverity(severity.Severity())
+		summary := StructuredData{}
+		summary.Element("throttle").
+			Set("severity", key).
+			Set("suppressed", strconv.Itoa(suppressed))
+		err := r.l.Log(log_level.NOTICE, "", summary, "%s suppressed %d messages", strings.ToUpper(key), suppressed)
+		if err != nil {
+			// the next message that passes logs the summary again
+			r.buckets[severity.Severity()].addSuppressed(suppressed)
+			summaryErr = fmt.Errorf("syslog: summary of suppressed messages: %w", err)
+		}
+	}
+	if err := r.l.Log(severity, msgId, sd, msgFormat, a...); err != nil {
+		return err
+	}
+	return summaryErr
+}
@@ -0,0 +1,112 @@
+package syslog_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_rate_limiter_per_severity(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewRateLimiter(syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid"), 1, 2)
+
+	for i := 0; i < 10; i++ {
+		l.Log(log_level.DEBUG, "", nil, "debug %d", i)
+	}
+	l.Log(log_level.CRITICAL, "", nil, "critical")
+
+	if strings.Count(buf.String(), " debug ") != 2 {
+		t.Fatalf("got output: %s, but expected the burst of 2 debug messages", buf.String())
+	}
+	if !strings.Contains(buf.String(), " critical\n") {
+		t.Fatalf("got output: %s, but expected the critical message", buf.String())
+	}
+}
+
+func Test_rate_limiter_summary(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewRateLimiter(syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid"), 20, 1)
+
+	for i := 0; i < 5; i++ {
+		l.Log(log_level.ERROR, "", nil, "error %d", i)
+	}
+	time.Sleep(60 * time.Millisecond)
+	l.Log(log_level.ERROR, "", nil, "recovered")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got lines: %q, but expected 3", lines)
+	}
+	expected := ` [throttle severity="err" suppressed="4"] ERR suppressed 4 messages`
+	if !strings.HasPrefix(lines[1], "<13>1 ") || !strings.HasSuffix(lines[1], expected) {
+		t.Fatalf("got summary: %s, but expected a NOTICE with suffix: %s", lines[1], expected)
+	}
+	if !strings.HasSuffix(lines[2], " recovered") {
+		t.Fatalf("got: %s, but expected the message after the summary", lines[2])
+	}
+}
+
+func Test_rate_limiter_concurrent(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewRateLimiter(syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid"), 1, 5)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				l.Log(log_level.INFO, "", nil, "message")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := strings.Count(buf.String(), "\n"); n != 5 {
+		t.Fatalf("got %d messages, but expected the burst of 5", n)
+	}
+}
+
+// failingNoticeLogger fails to log NOTICE messages while fail is set
+// and records the other messages.
+type failingNoticeLogger struct {
+	fail     bool
+	messages []string
+}
+
+func (l *failingNoticeLogger) Log(severity log_level.Priority, msgId string, sd syslog.StructuredData, msgFormat string, a ...interface{}) error {
+	if severity == log_level.NOTICE && l.fail {
+		return errors.New("write failed")
+	}
+	l.messages = append(l.messages, fmt.Sprintf(msgFormat, a...))
+	return nil
+}
+
+func Test_rate_limiter_reports_failed_summary(t *testing.T) {
+	inner := &failingNoticeLogger{fail: true}
+	l := syslog.NewRateLimiter(inner, 20, 1)
+
+	l.Log(log_level.ERROR, "", nil, "first")
+	l.Log(log_level.ERROR, "", nil, "dropped")
+	time.Sleep(60 * time.Millisecond)
+	if err := l.Log(log_level.ERROR, "", nil, "second"); err == nil {
+		t.Fatalf("expected the error of the failed summary")
+	}
+
+	inner.fail = false
+	time.Sleep(60 * time.Millisecond)
+	if err := l.Log(log_level.ERROR, "", nil, "third"); err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+	expected := []string{"first", "second", "ERR suppressed 1 messages", "third"}
+	if !reflect.DeepEqual(inner.messages, expected) {
+		t.Fatalf("got messages: %q, but expected: %q", inner.messages, expected)
+	}
+}
@@ -0,0 +1,150 @@
+package syslog
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WithByteRateLimit limits the number of bytes written per second, to
+// protect a link with limited bandwidth. The limit is measured on the
+// formatted messages: on average at most bytesPerSec bytes are written
+// per second, with bursts of up to burst bytes. A message larger than
+// burst is let through when no bytes were written for a while.
+//
+// Messages that exceed the limit are dropped, unless
+// WithRateLimitBlocking is given as well. The first message that is
+// written after messages were dropped records the number of dropped
+// messages in the structured data:
+//
+//	[throttle suppressed="12"]
+func WithByteRateLimit(bytesPerSec int, burst int) Option {
+	return func(o *options) {
+		o.byteLimit = newTokenBucket(float64(bytesPerSec), float64(burst))
+	}
+}
+
+// WithRateLimitBlocking makes messages that exceed a rate limit wait
+// until they fit in the limit instead of being dropped.
+func WithRateLimitBlocking() Option {
+	return func(o *options) {
+		o.blockOnLimit = true
+	}
+}
+
+// limitBytes returns ErrDropped when a message of n bytes exceeds the
+// byte rate limit and should be dropped. In blocking mode it waits
+// until the message fits in the limit instead.
+func (o *options) limitBytes(n int) error {
+	if o.byteLimit == nil {
+		return nil
+	}
+	if o.blockOnLimit {
+		o.byteLimit.wait(float64(n))
+		return nil
+	}
+	if !o.byteLimit.take(float64(n)) {
+		return ErrDropped
+	}
+	return nil
+}
+
+// tokenBucket refills at rate tokens per second up to burst tokens.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	// suppressed is the number of messages dropped since the last
+	// message that was let through.
+	suppressed int
+}
+
+func newTokenBucket(rate, burst float64) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
+
+// required returns the number of tokens that must be available to
+// take n tokens. Taking more tokens than the burst is allowed when
+// the bucket is full, leaving it in debt.
+func (b *tokenBucket) required(n float64) float64 {
+	if n > b.burst {
+		return b.burst
+	}
+	return n
+}
+
+// take takes n tokens if they are available.
+func (b *tokenBucket) take(n float64) bool {
+	ok, _ := b.takeSuppressed(n)
+	return ok
+}
+
+// takeSuppressed takes n tokens if they are available and returns the
+// number of takes that failed since the previous successful take.
+func (b *tokenBucket) takeSuppressed(n float64) (bool, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(time.Now())
+	if b.tokens < b.required(n) {
+		b.suppressed++
+		return false, 0
+	}
+	b.tokens -= n
+	suppressed := b.suppressed
+	b.suppressed = 0
+	return true, suppressed
+}
+
+// addSuppressed adds n to the number of dropped messages.
+func (b *tokenBucket) addSuppressed(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.suppressed += n
+}
+
+// suppressedCount returns the number of messages dropped since the
+// last message that was let through.
+func (b *tokenBucket) suppressedCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.suppressed
+}
+
+// setSuppressed records the number of dropped messages in sd.
+func (o *options) setSuppressed(sd StructuredData) {
+	if n := o.byteLimit.suppressedCount(); n > 0 {
+		sd.Element("throttle").Set("suppressed", strconv.Itoa(n))
+	}
+}
+
+// wait takes n tokens, waiting until they are available. The tokens
+// are reserved right away, leaving the bucket in debt, so the wait
+// doesn't hold the lock and later callers wait their turn.
+func (b *tokenBucket) wait(n float64) {
+	b.mu.Lock()
+	b.refill(time.Now())
+	missing := b.required(n) - b.tokens
+	b.tokens -= n
+	b.mu.Unlock()
+
+	if missing > 0 {
+		time.Sleep(time.Duration(missing / b.rate * float64(time.Second)))
+	}
+}
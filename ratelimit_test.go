@@ -0,0 +1,83 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_byte_rate_limit(t *testing.T) {
+	var dropped int
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithByteRateLimit(100, 1200),
+		syslog.WithHooks(nil, func(severity log_level.Priority, n int, err error) {
+			if err == syslog.ErrDropped {
+				dropped++
+			}
+		}))
+
+	large := strings.Repeat("x", 300)
+	for i := 0; i < 10; i++ {
+		l.Log(log_level.INFO, "", nil, large)
+	}
+
+	written := strings.Count(buf.String(), "\n")
+	if written != 3 {
+		t.Fatalf("got %d messages written, but expected the burst to allow 3", written)
+	}
+	if dropped != 7 {
+		t.Fatalf("got %d messages dropped, but expected 7", dropped)
+	}
+}
+
+func Test_byte_rate_limit_blocking(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123",
+		syslog.WithByteRateLimit(10000, 1000), syslog.WithRateLimitBlocking())
+
+	large := []byte(strings.Repeat("x", 900))
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		w.Write(large)
+	}
+
+	if written := strings.Count(buf.String(), "\n"); written != 3 {
+		t.Fatalf("got %d messages written, but expected all 3", written)
+	}
+	// the second and third message each wait for about 95ms
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("got %v elapsed, but expected the writes to be throttled", elapsed)
+	}
+}
+
+func Test_byte_rate_limit_records_suppressed_messages(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithByteRateLimit(1000, 200))
+
+	large := []byte(strings.Repeat("x", 150))
+	for i := 0; i < 4; i++ {
+		w.Write(large)
+	}
+	time.Sleep(250 * time.Millisecond)
+	w.Write(large)
+	time.Sleep(250 * time.Millisecond)
+	w.Write(large)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d messages written, but expected: 3", len(lines))
+	}
+	if strings.Contains(lines[0], "throttle") {
+		t.Fatalf("got: %s, but expected no throttle element before messages are dropped", lines[0])
+	}
+	if !strings.Contains(lines[1], `[throttle suppressed="3"]`) {
+		t.Fatalf("got: %s, but expected 3 suppressed messages", lines[1])
+	}
+	if strings.Contains(lines[2], "throttle") {
+		t.Fatalf("got: %s, but expected the suppressed count to be reset", lines[2])
+	}
+}
@@ -0,0 +1,21 @@
+package syslog
+
+const relayElement = "relay"
+
+// WithRelayID records id in the relay chain of every message as the
+// id param of the relay structured data element. Messages that are
+// already formatted, such as those forwarded by another relay, keep
+// their chain and get id appended to it, separated by a comma.
+func WithRelayID(id string) Option {
+	return func(o *options) {
+		o.relayID = id
+	}
+}
+
+func appendRelayID(sd StructuredData, id string) {
+	elem := sd.Element(relayElement)
+	if chain := elem.Get("id"); chain != "" {
+		id = chain + "," + id
+	}
+	elem.Set("id", id)
+}
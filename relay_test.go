@@ -0,0 +1,51 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"log"
+	"strings"
+	"testing"
+)
+
+func Test_relay_id(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithRelayID("relay1"))
+	log.New(w, "", 0).Println("message")
+
+	expected := ` - [relay id="relay1"] message` + "\n"
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Fatalf("got: %s, but expected suffix: %s", buf.String(), expected)
+	}
+}
+
+func Test_relay_id_appends_to_chain(t *testing.T) {
+	buf := &bytes.Buffer{}
+	second := syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "relay", "relayapp", "1", syslog.WithRelayID("relay2"))
+	first := syslog.NewWriter(second, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithRelayID("relay1"))
+	log.New(first, "", 0).Println("message")
+
+	expected := ` laptop testapp 123 - [relay id="relay1,relay2"] message` + "\n"
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Fatalf("got: %s, but expected suffix: %s", buf.String(), expected)
+	}
+}
+
+func Test_relay_id_keeps_call_site_structured_data(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithRelayID("relay2"))
+
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+	sd.Element("relay").Set("id", "relay1")
+	l.Log(log_level.ERROR, "LoginFailed", sd, "login failed")
+
+	expected := ` LoginFailed [id1 par1="val1"][relay id="relay1,relay2"] login failed` + "\n"
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Fatalf("got: %s, but expected suffix: %s", buf.String(), expected)
+	}
+	if sd.Element("relay").Get("id") != "relay1" {
+		t.Fatalf("structured data of the caller was modified: %v", sd)
+	}
+}
@@ -0,0 +1,78 @@
+package syslog
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog/log_level"
+	"io"
+	"strconv"
+	"time"
+)
+
+// rfc3164TimeFormat is the timestamp layout of RFC 3164, with the day
+// padded by a space.
+const rfc3164TimeFormat = "Jan _2 15:04:05"
+
+// maxTagLength is the maximum length of the TAG field in RFC 3164.
+const maxTagLength = 32
+
+// NewWriterRFC3164 returns a Writer that generates syslog messages in
+// the legacy BSD format defined in RFC 3164 instead of RFC 5424:
+//
+//	<PRI>Mmm dd hh:mm:ss HOSTNAME TAG: MSG
+//
+// The returned Writer is NOT safe for concurrent use by multiple
+// goroutines.
+func NewWriterRFC3164(out io.Writer, pri log_level.Priority, hostname, tag string, opts ...Option) *Writer {
+	return NewWriter(out, pri, hostname, tag, "", append([]Option{WithFormatter(NewRFC3164Formatter())}, opts...)...)
+}
+
+// NewRFC3164Formatter returns a Formatter that formats messages in the
+// legacy BSD format defined in RFC 3164. The app name is used as the
+// TAG, truncated to 32 characters, and followed by the procid in
+// brackets when it is set. The msgid is dropped and the structured
+// data, which RFC 3164 has no place for, is appended to the message.
+func NewRFC3164Formatter() Formatter {
+	return rfc3164Formatter{}
+}
+
+type rfc3164Formatter struct{}
+
+func (rfc3164Formatter) Format(
+	pri log_level.Priority,
+	timestamp time.Time,
+	hostname string,
+	appName string,
+	procid string,
+	_ string,
+	sd StructuredData,
+	msg []byte,
+) []byte {
+	msg = bytes.TrimRight(msg, "\n")
+
+	buf := &bytes.Buffer{}
+	buf.WriteByte('<')
+	buf.WriteString(strconv.Itoa(int(pri)))
+	buf.WriteByte('>')
+	buf.WriteString(timestamp.Format(rfc3164TimeFormat))
+	buf.WriteByte(' ')
+	buf.WriteString(defaultIfEmpty(hostname, "-"))
+	buf.WriteByte(' ')
+	if appName != "" {
+		buf.WriteString(truncate(appName, maxTagLength))
+		if procid != "" {
+			buf.WriteByte('[')
+			buf.WriteString(procid)
+			buf.WriteByte(']')
+		}
+		buf.WriteString(": ")
+	}
+	buf.Write(msg)
+	if sd := sd.String(); sd != "" {
+		if len(msg) > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(sd)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
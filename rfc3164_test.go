@@ -0,0 +1,37 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"log"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_writer_rfc3164(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriterRFC3164(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp")
+	log.New(w, "", 0).Println("Start HTTP server (addr=:8080)")
+
+	expected := regexp.MustCompile(`^<13>[A-Z][a-z]{2} [ 123][0-9] \d\d:\d\d:\d\d laptop testapp: Start HTTP server \(addr=:8080\)\n$`)
+	if !expected.MatchString(buf.String()) {
+		t.Fatalf("non-expected output: %q", buf.String())
+	}
+}
+
+func Test_rfc3164_formatter(t *testing.T) {
+	ts := time.Date(2017, 8, 5, 3, 13, 15, 0, time.UTC)
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+
+	got := string(syslog.NewRFC3164Formatter().Format(
+		syslog.USER|log_level.ERROR, ts, "laptop", strings.Repeat("a", 40), "123", "msgid", sd, []byte("login failed\n")))
+
+	expected := "<11>Aug  5 03:13:15 laptop " + strings.Repeat("a", 32) + `[123]: login failed [id1 par1="val1"]` + "\n"
+	if got != expected {
+		t.Fatalf("got: %q, but expected: %q", got, expected)
+	}
+}
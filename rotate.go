@@ -0,0 +1,296 @@
+package syslog
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rotateTimeFormat is the suffix of rolled files. It sorts in the
+// order in which the files are rolled.
+const rotateTimeFormat = "20060102T150405.000"
+
+// WithCompressRotated makes a RotatingFileWriter gzip the files it
+// rolls, adding the .gz extension. Other writers and the loggers
+// report an error when it is given.
+func WithCompressRotated() Option {
+	return func(o *options) {
+		o.compressRotated = true
+	}
+}
+
+// WithRetention makes a RotatingFileWriter delete rolled files: all
+// but the newest maxFiles and those rolled longer than maxAge ago. A
+// zero maxFiles or maxAge disables that limit. Other writers and the
+// loggers report an error when it is given.
+func WithRetention(maxFiles int, maxAge time.Duration) Option {
+	return func(o *options) {
+		o.retainFiles = maxFiles
+		o.retainAge = maxAge
+	}
+}
+
+// RotatingFileWriter writes to a file that is rolled when it would
+// grow beyond a maximum size. See NewRotatingFileWriter.
+type RotatingFileWriter struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+	opts    options
+	closed  bool
+
+	// background serializes the compression and pruning of rolled
+	// files, which run on their own goroutine.
+	background sync.Mutex
+	pending    sync.WaitGroup
+}
+
+// NewRotatingFileWriter returns an io.WriteCloser that appends to the
+// file at path. Before a write makes the file larger than maxSize
+// bytes, the file is renamed to path.TIMESTAMP, for example
+// app.log.20210304T050607.000, and a new file is created. A single
+// write is never split over two files.
+//
+// Rolled files are compressed with WithCompressRotated and deleted
+// with WithRetention. Both run in the background, they don't block
+// writes.
+// The returned writer is safe for concurrent use by multiple
+// goroutines.
+func NewRotatingFileWriter(path string, maxSize int64, opts ...Option) (*RotatingFileWriter, error) {
+	w := &RotatingFileWriter{
+		path:    path,
+		maxSize: maxSize,
+		opts:    newOptions(opts),
+	}
+	if err := w.opts.unsupported(rotatingTarget); err != nil {
+		return nil, err
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *RotatingFileWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+// Write writes p to the current file, rolling the file first when p
+// doesn't fit.
+func (w *RotatingFileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+	// a file that failed to reopen after a rotation is retried
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+	if w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *RotatingFileWriter) rotate() error {
+	err := w.file.Close()
+	w.file = nil
+	if err != nil {
+		return err
+	}
+
+	// the clock is only read here, under w.mu, the background work
+	// gets the time of the rotation
+	now := w.opts.clock()
+	rolled := w.path + "." + now.Format(rotateTimeFormat)
+	for i := 1; exists(rolled) || exists(rolled+".gz"); i++ {
+		rolled = fmt.Sprintf("%s.%s.%d", w.path, now.Format(rotateTimeFormat), i)
+	}
+	if err := os.Rename(w.path, rolled); err != nil {
+		// keep writing to the original file
+		if oerr := w.open(); oerr != nil {
+			return oerr
+		}
+		return err
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	if w.opts.compressRotated || w.opts.retainFiles > 0 || w.opts.retainAge > 0 {
+		w.pending.Add(1)
+		go func() {
+			defer w.pending.Done()
+			w.background.Lock()
+			defer w.background.Unlock()
+			if w.opts.compressRotated {
+				compressFile(rolled)
+			}
+			w.prune(now)
+		}()
+	}
+	return nil
+}
+
+// Close closes the current file and waits until the rolled files are
+// compressed and pruned.
+func (w *RotatingFileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending.Wait()
+	w.closed = true
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// compressFile replaces the file at path by a gzipped copy with the
+// .gz extension. On failure the uncompressed file is kept.
+func compressFile(path string) {
+	src, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	tmp := path + ".gz.tmp"
+	dst, err := os.Create(tmp)
+	if err != nil {
+		return
+	}
+	zw := gzip.NewWriter(dst)
+	_, err = io.Copy(zw, src)
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path+".gz")
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return
+	}
+	os.Remove(path)
+}
+
+// prune deletes the rolled files that exceed the retention limits at
+// the time now.
+func (w *RotatingFileWriter) prune(now time.Time) {
+	if w.opts.retainFiles <= 0 && w.opts.retainAge <= 0 {
+		return
+	}
+	dir, base := filepath.Split(w.path)
+	if dir == "" {
+		dir = "."
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	var rolled []rolledFile
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		if f, ok := parseRolledName(base, info.Name()); ok {
+			f.info = info
+			rolled = append(rolled, f)
+		}
+	}
+	// newest first
+	sort.Slice(rolled, func(i, j int) bool {
+		if !rolled[i].at.Equal(rolled[j].at) {
+			return rolled[i].at.After(rolled[j].at)
+		}
+		return rolled[i].n > rolled[j].n
+	})
+
+	for i, f := range rolled {
+		tooMany := w.opts.retainFiles > 0 && i >= w.opts.retainFiles
+		tooOld := w.opts.retainAge > 0 && now.Sub(f.info.ModTime()) > w.opts.retainAge
+		if tooMany || tooOld {
+			os.Remove(filepath.Join(dir, f.info.Name()))
+		}
+	}
+}
+
+// rolledFile is a file in the directory of the log that was rolled by
+// a RotatingFileWriter.
+type rolledFile struct {
+	info os.FileInfo
+	at   time.Time
+	n    int
+}
+
+// parseRolledName reports whether name is base followed by
+// ".<rotateTimeFormat>[.N][.gz]", the names the writer gives rolled
+// files. Other files next to the log, like "app.log.bak", never match.
+func parseRolledName(base, name string) (rolledFile, bool) {
+	if !strings.HasPrefix(name, base+".") {
+		return rolledFile{}, false
+	}
+	rest := strings.TrimSuffix(name[len(base)+1:], ".gz")
+	if len(rest) < len(rotateTimeFormat) {
+		return rolledFile{}, false
+	}
+	at, err := time.Parse(rotateTimeFormat, rest[:len(rotateTimeFormat)])
+	if err != nil {
+		return rolledFile{}, false
+	}
+	f := rolledFile{at: at}
+	rest = rest[len(rotateTimeFormat):]
+	if rest == "" {
+		return f, true
+	}
+	if rest[0] != '.' || strings.TrimLeft(rest[1:], "0123456789") != "" {
+		return rolledFile{}, false
+	}
+	n, err := strconv.Atoi(rest[1:])
+	if err != nil {
+		return rolledFile{}, false
+	}
+	f.n = n
+	return f, true
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
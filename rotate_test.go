@@ -0,0 +1,192 @@
+package syslog_test
+
+import (
+	"compress/gzip"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_rotating_file_writer_compresses_and_prunes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	path := filepath.Join(dir, "app.log")
+	rw, err := syslog.NewRotatingFileWriter(path, 200,
+		syslog.WithCompressRotated(),
+		syslog.WithRetention(2, 0),
+		syslog.WithClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+
+	l := syslog.NewLogger(rw, syslog.USER, "hostname", "appName", "procid")
+	for i := 0; i < 10; i++ {
+		now = now.Add(time.Second)
+		l.Log(log_level.INFO, "", nil, "message %d %s", i, strings.Repeat("x", 100))
+	}
+	if err := rw.Close(); err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+
+	matches, _ := filepath.Glob(path + ".*")
+	sort.Strings(matches)
+	if len(matches) != 2 {
+		t.Fatalf("got rolled files: %v, but expected the newest 2", matches)
+	}
+	for _, m := range matches {
+		if !strings.HasSuffix(m, ".gz") {
+			t.Fatalf("got rolled file: %s, but expected it to be gzipped", m)
+		}
+	}
+
+	// the last rolled file holds the message before the current file
+	f, err := os.Open(matches[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("got error: %v, but expected a gzip file", err)
+	}
+	content, _ := ioutil.ReadAll(zr)
+	if !strings.Contains(string(content), "message 8 ") {
+		t.Fatalf("got content: %s, but expected message 8", content)
+	}
+
+	current, _ := ioutil.ReadFile(path)
+	if !strings.Contains(string(current), "message 9 ") || strings.Count(string(current), "\n") != 1 {
+		t.Fatalf("got current file: %s, but expected only message 9", current)
+	}
+}
+
+func Test_rotating_file_writer_prunes_by_age(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.log")
+	old := path + ".20000101T000000.000"
+	ioutil.WriteFile(old, []byte("old\n"), 0644)
+	os.Chtimes(old, time.Now().Add(-48*time.Hour), time.Now().Add(-48*time.Hour))
+
+	rw, _ := syslog.NewRotatingFileWriter(path, 10, syslog.WithRetention(0, 24*time.Hour))
+	rw.Write([]byte("first message\n"))
+	rw.Write([]byte("second message\n"))
+	rw.Close()
+
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Fatalf("expected the file older than the max age to be deleted")
+	}
+	if matches, _ := filepath.Glob(path + ".*"); len(matches) != 1 {
+		t.Fatalf("got rolled files: %v, but expected 1", matches)
+	}
+}
+
+func Test_rotating_file_writer_only_prunes_rolled_files(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.log")
+	unrelated := []string{path + ".bak", path + ".1", path + ".old.gz", path + ".20000101T000000.000.x"}
+	for _, name := range unrelated {
+		ioutil.WriteFile(name, []byte("keep\n"), 0644)
+	}
+	older := path + ".20000101T000000.000.2"
+	ioutil.WriteFile(older, []byte("old\n"), 0644)
+
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	rw, _ := syslog.NewRotatingFileWriter(path, 10,
+		syslog.WithRetention(1, 0),
+		syslog.WithClock(func() time.Time { return now }),
+	)
+	rw.Write([]byte("first message\n"))
+	now = now.Add(time.Second)
+	rw.Write([]byte("second message\n"))
+	now = now.Add(time.Second)
+	rw.Write([]byte("third message\n"))
+	rw.Close()
+
+	for _, name := range unrelated {
+		if _, err := os.Stat(name); err != nil {
+			t.Fatalf("got error: %v, but expected %s to survive pruning", err, filepath.Base(name))
+		}
+	}
+	if _, err := os.Stat(older); !os.IsNotExist(err) {
+		t.Fatalf("expected the oldest rolled file to be deleted")
+	}
+	newest := path + ".20210304T050609.000"
+	if _, err := os.Stat(newest); err != nil {
+		t.Fatalf("got error: %v, but expected the newest rolled file to be kept", err)
+	}
+}
+
+func Test_rotation_options_are_rejected_by_other_writers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := syslog.NewRotatingFileWriter(filepath.Join(dir, "app.log"), 10, syslog.WithBatch(10, 0, 0)); err == nil {
+		t.Fatalf("expected an error for WithBatch on NewRotatingFileWriter")
+	}
+
+	w := syslog.NewWriter(ioutil.Discard, syslog.USER|log_level.INFO, "hostname", "appName", "procid",
+		syslog.WithRetention(2, 0))
+	if _, err := w.Write([]byte("message")); err == nil {
+		t.Fatalf("expected an error for WithRetention on NewWriter")
+	}
+
+	l := syslog.NewLogger(ioutil.Discard, syslog.USER, "hostname", "appName", "procid", syslog.WithCompressRotated())
+	if err := l.Log(log_level.INFO, "", nil, "message"); err == nil {
+		t.Fatalf("expected an error for WithCompressRotated on NewLogger")
+	}
+}
+
+func Test_rotating_file_writer_recovers_from_failed_rename(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.log")
+	rw, err := syslog.NewRotatingFileWriter(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rw.Close()
+	rw.Write([]byte("first message\n"))
+
+	// the rename of the rotation fails when the file is gone
+	os.Remove(path)
+	if _, err := rw.Write([]byte("second message\n")); err == nil {
+		t.Fatalf("expected an error for the failed rename")
+	}
+
+	if _, err := rw.Write([]byte("third message\n")); err != nil {
+		t.Fatalf("got error: %v, but expected the writer to keep working", err)
+	}
+	content, _ := ioutil.ReadFile(path)
+	if string(content) != "third message\n" {
+		t.Fatalf("got content: %q, but expected the third message", content)
+	}
+}
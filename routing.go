@@ -0,0 +1,57 @@
+package syslog
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"sort"
+)
+
+// NewRoutingLogger returns a Logger that sends every message to a
+// single destination, chosen by its severity. A route matches the
+// messages with a severity at or above its threshold, so
+//
+//	syslog.NewRoutingLogger(map[log_level.Priority]syslog.Logger{
+//		log_level.CRITICAL: pager,
+//		log_level.WARNING:  collector,
+//	}, debug)
+//
+// sends CRITICAL, ALERT and EMERGENCY messages to pager, ERROR and
+// WARNING messages to collector and the other messages to fallback.
+// A message that matches several routes goes to the most specific
+// one: the route with the highest threshold severity. Messages that
+// match no route are dropped if fallback is nil.
+func NewRoutingLogger(routes map[log_level.Priority]Logger, fallback Logger) Logger {
+	r := routingLogger{fallback: fallback}
+	for threshold, l := range routes {
+		r.routes = append(r.routes, route{threshold.Severity(), l})
+	}
+	sort.Slice(r.routes, func(i, j int) bool {
+		return r.routes[i].threshold < r.routes[j].threshold
+	})
+	return r
+}
+
+type route struct {
+	threshold log_level.Priority
+	logger    Logger
+}
+
+type routingLogger struct {
+	// routes are ordered from the highest to the lowest threshold
+	// severity, so the first match is the most specific one
+	routes   []route
+	fallback Logger
+}
+
+func (r routingLogger) Log(severity log_level.Priority, msgId string, sd StructuredData, msgFormat string, a ...interface{}) error {
+	l := r.fallback
+	for _, route := range r.routes {
+		if severity.Severity() <= route.threshold {
+			l = route.logger
+			break
+		}
+	}
+	if l == nil {
+		return nil
+	}
+	return l.Log(severity, msgId, sd, msgFormat, a...)
+}
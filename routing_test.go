@@ -0,0 +1,61 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+func Test_routing_logger(t *testing.T) {
+	pager, collector, fallback := &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
+	newLogger := func(buf *bytes.Buffer) syslog.Logger {
+		return syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid")
+	}
+	l := syslog.NewRoutingLogger(map[log_level.Priority]syslog.Logger{
+		log_level.CRITICAL: newLogger(pager),
+		log_level.WARNING:  newLogger(collector),
+	}, newLogger(fallback))
+
+	for _, severity := range []log_level.Priority{
+		log_level.EMERGENCY, log_level.CRITICAL, log_level.ERROR, log_level.WARNING, log_level.NOTICE, log_level.DEBUG,
+	} {
+		l.Log(severity, "", nil, "message")
+	}
+
+	tests := []struct {
+		name     string
+		buf      *bytes.Buffer
+		prefixes []string
+	}{
+		{"pager", pager, []string{"<8>1", "<10>1"}},
+		{"collector", collector, []string{"<11>1", "<12>1"}},
+		{"fallback", fallback, []string{"<13>1", "<15>1"}},
+	}
+	for _, test := range tests {
+		lines := strings.Split(strings.TrimSpace(test.buf.String()), "\n")
+		if len(lines) != len(test.prefixes) {
+			t.Fatalf("got %s lines: %q, but expected prefixes: %v", test.name, lines, test.prefixes)
+		}
+		for i, prefix := range test.prefixes {
+			if !strings.HasPrefix(lines[i], prefix) {
+				t.Fatalf("got %s line: %s, but expected prefix: %s", test.name, lines[i], prefix)
+			}
+		}
+	}
+}
+
+func Test_routing_logger_without_fallback(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewRoutingLogger(map[log_level.Priority]syslog.Logger{
+		log_level.ERROR: syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid"),
+	}, nil)
+
+	if err := l.Log(log_level.INFO, "", nil, "message"); err != nil {
+		t.Fatalf("got error: %v, but expected: <nil>", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("got: %q, but expected no output", buf.String())
+	}
+}
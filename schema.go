@@ -0,0 +1,102 @@
+package syslog
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ParamType is the type of the value of a structured data param.
+type ParamType int
+
+const (
+	// ParamString accepts any value.
+	ParamString ParamType = iota
+	// ParamInt accepts values that can be parsed by strconv.Atoi.
+	ParamInt
+	// ParamFloat accepts values that can be parsed by strconv.ParseFloat.
+	ParamFloat
+	// ParamBool accepts values that can be parsed by strconv.ParseBool.
+	ParamBool
+)
+
+func (t ParamType) String() string {
+	switch t {
+	case ParamString:
+		return "string"
+	case ParamInt:
+		return "int"
+	case ParamFloat:
+		return "float"
+	case ParamBool:
+		return "bool"
+	}
+	return "ParamType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// Schema declares the types of structured data param values, keyed
+// by SD-ID and param name. Params that are not declared are not
+// checked.
+type Schema map[string]map[string]ParamType
+
+// Validate returns an error for the first param in sd whose value
+// can't be coerced to the type declared by the schema.
+func (s Schema) Validate(sd StructuredData) error {
+	for _, id := range sd.Ids() {
+		types, ok := s[id]
+		if !ok {
+			continue
+		}
+		elem := sd[id]
+		for _, name := range elem.Names() {
+			t, ok := types[name]
+			if !ok {
+				continue
+			}
+			if err := t.check(elem[name]); err != nil {
+				return fmt.Errorf("syslog: param %s of SD-ID %s: value %q is not a valid %s", name, id, elem[name], t)
+			}
+		}
+	}
+	return nil
+}
+
+func (t ParamType) check(value string) error {
+	var err error
+	switch t {
+	case ParamInt:
+		_, err = strconv.Atoi(value)
+	case ParamFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case ParamBool:
+		_, err = strconv.ParseBool(value)
+	}
+	return err
+}
+
+// WithStrictSchema validates the structured data of every message
+// against the schema before it is written. A message that doesn't
+// match the schema is not written, the validation error is returned
+// by Write and passed to the after hook instead.
+func WithStrictSchema(schema Schema) Option {
+	return func(o *options) {
+		o.schema = schema
+	}
+}
+
+func (o *options) validateSchema(sd StructuredData) error {
+	if o.schema == nil {
+		return nil
+	}
+	return o.schema.Validate(sd)
+}
+
+// WithSchemaVersion adds the version param of the schema element to
+// every message, so consumers can handle changes in the format of the
+// structured data:
+//
+//	[schema version="2"]
+func WithSchemaVersion(version string) Option {
+	return func(o *options) {
+		o.schemaVersion = version
+	}
+}
@@ -0,0 +1,89 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+var testSchema = syslog.Schema{
+	"request@32473": {
+		"status":   syslog.ParamInt,
+		"duration": syslog.ParamFloat,
+		"cached":   syslog.ParamBool,
+		"path":     syslog.ParamString,
+	},
+}
+
+func Test_schema_validate(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("request@32473").
+		Set("status", "200").
+		Set("duration", "0.25").
+		Set("cached", "true").
+		Set("path", "/users").
+		Set("undeclared", "anything")
+	if err := testSchema.Validate(sd); err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+
+	sd.Element("request@32473").Set("status", "OK")
+	if err := testSchema.Validate(sd); err == nil {
+		t.Fatalf("expected an error for a non-numeric int param")
+	}
+}
+
+func Test_strict_schema_does_not_write_invalid_message(t *testing.T) {
+	var afterErr error
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithStrictSchema(testSchema),
+		syslog.WithHooks(nil, func(severity log_level.Priority, n int, err error) {
+			afterErr = err
+		}),
+	)
+
+	sd := syslog.StructuredData{}
+	sd.Element("request@32473").Set("status", "not a number")
+	l.Log(log_level.INFO, "", sd, "request handled")
+
+	expected := `syslog: param status of SD-ID request@32473: value "not a number" is not a valid int`
+	if afterErr == nil || afterErr.Error() != expected {
+		t.Fatalf("got error: %v, but expected: %v", afterErr, expected)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("got output: %q, but expected nothing", buf.String())
+	}
+
+	sd.Element("request@32473").Set("status", "200")
+	l.Log(log_level.INFO, "", sd, "request handled")
+	if afterErr != nil || buf.Len() == 0 {
+		t.Fatalf("got error: %v and output: %q, but expected the message", afterErr, buf.String())
+	}
+}
+
+func Test_schema_version(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithSchemaVersion("2"))
+	w.Write([]byte("first"))
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithSchemaVersion("2"))
+	sd := syslog.StructuredData{}
+	sd.Element("request@32473").Set("status", "200")
+	l.Log(log_level.INFO, "", sd, "second")
+	l.Log(log_level.INFO, "", nil, "third")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, but expected: 3", len(lines))
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `[schema version="2"]`) {
+			t.Fatalf("got line: %s, but expected the schema version", line)
+		}
+	}
+	if len(sd) != 1 {
+		t.Fatalf("expected the structured data of the caller to be left untouched")
+	}
+}
@@ -0,0 +1,239 @@
+package syslog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultDBQueryMaxLength is the maximum length in bytes of the query
+// statement stored by SetDBQuery. Longer statements are truncated.
+const DefaultDBQueryMaxLength = 1024
+
+// SetDBQuery stores metadata about a database query in the db
+// element: the query statement, its duration in milliseconds and
+// the number of rows affected. The statement is truncated to
+// DefaultDBQueryMaxLength bytes, use SetDBQueryN for another limit.
+func (d StructuredData) SetDBQuery(statement string, durationMs int, rows int) SDElement {
+	return d.SetDBQueryN(statement, durationMs, rows, DefaultDBQueryMaxLength)
+}
+
+// SetDBQueryN is like SetDBQuery, but truncates the statement to
+// maxLength bytes. A negative maxLength keeps the whole statement.
+func (d StructuredData) SetDBQueryN(statement string, durationMs int, rows int, maxLength int) SDElement {
+	return d.Element("db").
+		Set("query", truncate(statement, maxLength)).
+		Set("duration_ms", strconv.Itoa(durationMs)).
+		Set("rows", strconv.Itoa(rows))
+}
+
+// truncate shortens s to at most max bytes without splitting a
+// UTF-8 encoded rune.
+func truncate(s string, max int) string {
+	if max < 0 || len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
+
+// ciEnv maps the params of the ci element to the environment
+// variables that hold their value, in order of preference.
+var ciEnv = []struct {
+	param string
+	env   []string
+}{
+	{"commit", []string{"CI_COMMIT_SHA", "GITHUB_SHA"}},
+	{"ref", []string{"CI_COMMIT_REF_NAME", "GITHUB_REF"}},
+	{"repository", []string{"CI_PROJECT_PATH", "GITHUB_REPOSITORY"}},
+	{"pipeline", []string{"CI_PIPELINE_ID", "GITHUB_RUN_ID"}},
+	{"job", []string{"CI_JOB_ID", "GITHUB_JOB"}},
+}
+
+// SetCIContext stores the build metadata exposed by GitLab CI and
+// GitHub Actions in the ci element. Params of which the environment
+// variable is not set are skipped.
+func (d StructuredData) SetCIContext() SDElement {
+	elem := d.Element("ci")
+	for _, p := range ciEnv {
+		for _, env := range p.env {
+			if value := os.Getenv(env); value != "" {
+				elem.Set(p.param, value)
+				break
+			}
+		}
+	}
+	return elem
+}
+
+// SetFlags stores the state of feature flags, one param per flag
+// with the value "true" or "false".
+func (e SDElement) SetFlags(flags map[string]bool) SDElement {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		e.Set(name, strconv.FormatBool(flags[name]))
+	}
+	return e
+}
+
+// SetRetry stores the state of a retried operation: the current
+// attempt, the maximum number of attempts and, unless lastErr is nil,
+// the error of the previous attempt.
+func (e SDElement) SetRetry(attempt, maxAttempts int, lastErr error) SDElement {
+	e.Set("attempt", strconv.Itoa(attempt))
+	e.Set("max_attempts", strconv.Itoa(maxAttempts))
+	if lastErr != nil {
+		e.Set("last_error", lastErr.Error())
+	} else {
+		delete(e, "last_error")
+	}
+	return e
+}
+
+// SetTimings stores named durations, such as the phases of a request,
+// as one name_ms param per duration holding whole milliseconds.
+func (e SDElement) SetTimings(timings map[string]time.Duration) SDElement {
+	names := make([]string, 0, len(timings))
+	for name := range timings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		e.Set(name+"_ms", strconv.FormatInt(int64(timings[name]/time.Millisecond), 10))
+	}
+	return e
+}
+
+// SetException classifies an error: type holds the dynamic type of
+// err, such as *os.PathError, message its text and category the
+// given category. For a nil error only the category is stored.
+func (e SDElement) SetException(err error, category string) SDElement {
+	if err != nil {
+		e.Set("type", reflect.TypeOf(err).String())
+		e.Set("message", err.Error())
+	}
+	return e.Set("category", category)
+}
+
+// SetAudit stores the outcome of an authorization decision in the
+// audit element. The outcome must be "allow", "deny" or "error",
+// otherwise an error is returned and the structured data is left
+// unchanged.
+func (d StructuredData) SetAudit(actor, action, resource, outcome string) error {
+	switch outcome {
+	case "allow", "deny", "error":
+	default:
+		return fmt.Errorf("syslog: invalid audit outcome %q, expected allow, deny or error", outcome)
+	}
+	d.Element("audit").
+		Set("actor", actor).
+		Set("action", action).
+		Set("resource", resource).
+		Set("outcome", outcome)
+	return nil
+}
+
+// SetSampled stores the sampling decision of the current trace: the
+// sampled param holds "true" or "false" and sample_rate the rate at
+// which traces are sampled, for example "0.25".
+func (e SDElement) SetSampled(sampled bool, sampleRate float64) SDElement {
+	return e.
+		Set("sampled", strconv.FormatBool(sampled)).
+		Set("sample_rate", strconv.FormatFloat(sampleRate, 'g', -1, 64))
+}
+
+// SetMoney stores an amount given in minor units, such as cents, with
+// two decimals in the name param and its currency code in the
+// currency param. 12345 minor units in USD are stored as
+// amount="123.45" currency="USD".
+func (e SDElement) SetMoney(name string, minorUnits int64, currency string) SDElement {
+	sign := ""
+	units := uint64(minorUnits)
+	if minorUnits < 0 {
+		sign = "-"
+		units = uint64(-minorUnits)
+	}
+	cents := units % 100
+	amount := sign + strconv.FormatUint(units/100, 10) + "." + strconv.FormatUint(cents/10, 10) + strconv.FormatUint(cents%10, 10)
+	return e.Set(name, amount).Set("currency", currency)
+}
+
+// SetValidationErrors stores the error message of every invalid field
+// in a param named after the field.
+func (e SDElement) SetValidationErrors(errs map[string]string) SDElement {
+	fields := make([]string, 0, len(errs))
+	for field := range errs {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		e.Set(field, errs[field])
+	}
+	return e
+}
+
+// SetBrokerMessage stores the position of a message consumed from or
+// produced to a message broker, such as Kafka, in the broker element.
+// The key param is omitted for messages without a key.
+func (d StructuredData) SetBrokerMessage(topic string, partition int, offset int64, key string) SDElement {
+	elem := d.Element("broker").
+		Set("topic", topic).
+		Set("partition", strconv.Itoa(partition)).
+		Set("offset", strconv.FormatInt(offset, 10))
+	if key != "" {
+		elem.Set("key", key)
+	}
+	return elem
+}
+
+// SetCacheStats stores the result of a cache lookup: the hit param
+// holds "true" or "false", key the cache key and ttl the remaining
+// time to live as formatted by time.Duration, for example "1m30s".
+func (e SDElement) SetCacheStats(hit bool, key string, ttl time.Duration) SDElement {
+	return e.
+		Set("hit", strconv.FormatBool(hit)).
+		Set("key", key).
+		Set("ttl", ttl.String())
+}
+
+// SetList stores an ordered list of values as indexed params, so
+// SetList("tag", []string{"a", "b"}) stores tag.0="a" tag.1="b". The
+// index keeps the order of the values, also in an element that is
+// written with its params in lexicographical order, where tag.10
+// comes before tag.2.
+func (e SDElement) SetList(name string, values []string) SDElement {
+	for i, value := range values {
+		e.Set(name+"."+strconv.Itoa(i), value)
+	}
+	return e
+}
+
+// SetTenant stores the tenant of a multi-tenant application in the
+// tenant element: its id, the id of its organization and its plan.
+// The org_id and plan params are omitted when empty. The tenant id is
+// required, without one an error is returned and the structured data
+// is left unchanged.
+func (d StructuredData) SetTenant(tenantID, orgID, plan string) error {
+	if tenantID == "" {
+		return errors.New("syslog: tenant id is required")
+	}
+	elem := d.Element("tenant").Set("id", tenantID)
+	if orgID != "" {
+		elem.Set("org_id", orgID)
+	}
+	if plan != "" {
+		elem.Set("plan", plan)
+	}
+	return nil
+}
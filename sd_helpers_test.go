@@ -0,0 +1,282 @@
+package syslog_test
+
+import (
+	"errors"
+	"github.com/confetti-framework/syslog"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_set_db_query(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.SetDBQuery("SELECT * FROM users", 12, 3)
+
+	expected := `[db duration_ms="12" query="SELECT * FROM users" rows="3"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_set_db_query_truncates_statement(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.SetDBQueryN("SELECT * FROM users WHERE id = 1", 12, 1, 10)
+
+	expected := "SELECT * F"
+	if query := sd.Element("db").Get("query"); query != expected {
+		t.Fatalf("got query: %v, but expected: %v", query, expected)
+	}
+
+	sd.SetDBQueryN("SELECT ééééé", 12, 1, 10)
+	if query := sd.Element("db").Get("query"); !strings.HasPrefix("SELECT ééééé", query) || len(query) != 9 {
+		t.Fatalf("got query: %q, but expected it to end on a rune boundary", query)
+	}
+
+	sd.SetDBQuery(strings.Repeat("x", 2000), 12, 1)
+	if query := sd.Element("db").Get("query"); len(query) != syslog.DefaultDBQueryMaxLength {
+		t.Fatalf("got query length: %v, but expected: %v", len(query), syslog.DefaultDBQueryMaxLength)
+	}
+}
+
+// setenv sets or, for an empty value, unsets an environment variable
+// for the duration of the test.
+func setenv(t *testing.T, key, value string) {
+	old, ok := os.LookupEnv(key)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+	if value == "" {
+		os.Unsetenv(key)
+	} else {
+		os.Setenv(key, value)
+	}
+}
+
+func Test_set_ci_context(t *testing.T) {
+	for _, key := range []string{
+		"CI_COMMIT_SHA", "CI_COMMIT_REF_NAME", "CI_PROJECT_PATH", "CI_PIPELINE_ID", "CI_JOB_ID",
+		"GITHUB_SHA", "GITHUB_REF", "GITHUB_REPOSITORY", "GITHUB_RUN_ID", "GITHUB_JOB",
+	} {
+		setenv(t, key, "")
+	}
+	setenv(t, "CI_COMMIT_SHA", "abc123")
+	setenv(t, "GITHUB_REF", "refs/heads/main")
+
+	sd := syslog.StructuredData{}
+	sd.SetCIContext()
+
+	expected := `[ci commit="abc123" ref="refs/heads/main"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_set_flags(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("flags").SetFlags(map[string]bool{
+		"new_checkout": true,
+		"dark_mode":    false,
+		"beta":         true,
+	})
+
+	expected := `[flags beta="true" dark_mode="false" new_checkout="true"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_set_retry(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("retry").SetRetry(2, 5, errors.New("connection refused"))
+
+	expected := `[retry attempt="2" last_error="connection refused" max_attempts="5"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+
+	sd.Element("retry").SetRetry(1, 5, nil)
+
+	expected = `[retry attempt="1" max_attempts="5"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_set_timings(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("timing").SetTimings(map[string]time.Duration{
+		"db":     42 * time.Millisecond,
+		"render": 1500 * time.Microsecond,
+		"auth":   2 * time.Second,
+	})
+
+	expected := `[timing auth_ms="2000" db_ms="42" render_ms="1"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+type quotaError struct {
+	limit int
+}
+
+func (e *quotaError) Error() string {
+	return "quota of " + strconv.Itoa(e.limit) + " exceeded"
+}
+
+func Test_set_exception(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("exception").SetException(&quotaError{10}, "quota")
+
+	expected := `[exception category="quota" message="quota of 10 exceeded" type="*syslog_test.quotaError"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_set_audit(t *testing.T) {
+	sd := syslog.StructuredData{}
+	if err := sd.SetAudit("alice", "delete", "invoice/42", "deny"); err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+
+	expected := `[audit action="delete" actor="alice" outcome="deny" resource="invoice/42"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_set_audit_invalid_outcome(t *testing.T) {
+	sd := syslog.StructuredData{}
+	err := sd.SetAudit("alice", "delete", "invoice/42", "maybe")
+
+	expected := `syslog: invalid audit outcome "maybe", expected allow, deny or error`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("got error: %v, but expected: %v", err, expected)
+	}
+	if len(sd) != 0 {
+		t.Fatalf("got structured data: %v, but expected it to be empty", sd.String())
+	}
+}
+
+func Test_set_sampled(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("trace").SetSampled(true, 0.25)
+
+	expected := `[trace sample_rate="0.25" sampled="true"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_set_money(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("payment").SetMoney("amount", 12345, "USD")
+
+	expected := `[payment amount="123.45" currency="USD"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+
+	for minorUnits, expected := range map[int64]string{
+		0:      "0.00",
+		5:      "0.05",
+		-5:     "-0.05",
+		-12345: "-123.45",
+		100:    "1.00",
+	} {
+		amount := syslog.SDElement{}.SetMoney("amount", minorUnits, "EUR").Get("amount")
+		if amount != expected {
+			t.Fatalf("got amount: %v for %d, but expected: %v", amount, minorUnits, expected)
+		}
+	}
+}
+
+func Test_set_validation_errors(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("validation").SetValidationErrors(map[string]string{
+		"email":    "is not a valid email address",
+		"password": "must have at least 8 characters",
+	})
+
+	expected := `[validation email="is not a valid email address" password="must have at least 8 characters"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_set_broker_message(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.SetBrokerMessage("orders", 3, 1234567890123, "customer-42")
+
+	expected := `[broker key="customer-42" offset="1234567890123" partition="3" topic="orders"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+
+	sd = syslog.StructuredData{}
+	sd.SetBrokerMessage("orders", 0, 0, "")
+	expected = `[broker offset="0" partition="0" topic="orders"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_set_cache_stats(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("cache").SetCacheStats(true, "user:42", 90*time.Second)
+
+	expected := `[cache hit="true" key="user:42" ttl="1m30s"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+
+	sd = syslog.StructuredData{}
+	sd.Element("cache").SetCacheStats(false, "user:43", 0)
+
+	expected = `[cache hit="false" key="user:43" ttl="0s"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_set_list(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("request").SetList("tag", []string{"b", "a", "b"})
+
+	expected := `[request tag.0="b" tag.1="a" tag.2="b"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_set_tenant(t *testing.T) {
+	sd := syslog.StructuredData{}
+	if err := sd.SetTenant("t-42", "org-7", "enterprise"); err != nil {
+		t.Fatalf("got error: %v, but expected nil", err)
+	}
+
+	expected := `[tenant id="t-42" org_id="org-7" plan="enterprise"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}
+
+func Test_set_tenant_missing_id(t *testing.T) {
+	sd := syslog.StructuredData{}
+	err := sd.SetTenant("", "org-7", "enterprise")
+
+	expected := "syslog: tenant id is required"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("got error: %v, but expected: %v", err, expected)
+	}
+	if len(sd) != 0 {
+		t.Fatalf("got structured data: %v, but expected it to be empty", sd.String())
+	}
+}
@@ -0,0 +1,65 @@
+package syslog
+
+import (
+	"strconv"
+	"strings"
+)
+
+// WithSDIDOrder makes a Writer or Logger write the SD elements in the
+// order of less, which reports whether the element with id a is
+// written before the one with id b, for example EnterpriseSDIDLess.
+// Without it the elements are written in lexicographical order of
+// their ids. It applies to the RFC 5424 format.
+func WithSDIDOrder(less func(a, b string) bool) Option {
+	return func(o *options) {
+		o.order.less = less
+	}
+}
+
+// EnterpriseSDIDLess orders the SD-IDs registered with IANA, such as
+// origin and timeQuality, before the ids of the form name@number. The
+// registered ids are sorted lexicographically, the others grouped by
+// their numeric private enterprise number and then sorted by name:
+//
+//	[origin ...][timeQuality ...][meta@9 ...][meta@32473 ...][trace@32473 ...]
+func EnterpriseSDIDLess(a, b string) bool {
+	nameA, numberA := splitSDID(a)
+	nameB, numberB := splitSDID(b)
+	if (numberA == "") != (numberB == "") {
+		return numberA == ""
+	}
+	if numberA != numberB {
+		return enterpriseNumberLess(numberA, numberB)
+	}
+	return nameA < nameB
+}
+
+func splitSDID(id string) (name, number string) {
+	if i := strings.LastIndexByte(id, '@'); i >= 0 {
+		return id[:i], id[i+1:]
+	}
+	return id, ""
+}
+
+// enterpriseNumberLess compares enterprise numbers such as 32473 or
+// 32473.1.2 numerically, component by component. Components that are
+// not numbers are compared lexicographically.
+func enterpriseNumberLess(a, b string) bool {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		if partsA[i] == partsB[i] {
+			continue
+		}
+		x, errA := strconv.ParseUint(partsA[i], 10, 64)
+		y, errB := strconv.ParseUint(partsB[i], 10, 64)
+		if errA != nil || errB != nil {
+			return partsA[i] < partsB[i]
+		}
+		if x != y {
+			return x < y
+		}
+		return partsA[i] < partsB[i]
+	}
+	return len(partsA) < len(partsB)
+}
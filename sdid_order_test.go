@@ -0,0 +1,60 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_enterprise_sdid_less(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithSDIDOrder(syslog.EnterpriseSDIDLess),
+	)
+
+	sd := syslog.StructuredData{}
+	for _, id := range []string{"trace@32473", "timeQuality", "meta@32473", "app@9", "origin", "x@32473.1", "y@100000"} {
+		sd.Element(id).Set("p", "v")
+	}
+	l.Log(log_level.INFO, "", sd, "message")
+
+	expected := ` [origin p="v"][timeQuality p="v"][app@9 p="v"][meta@32473 p="v"][trace@32473 p="v"][x@32473.1 p="v"][y@100000 p="v"] message`
+	if !strings.Contains(buf.String(), expected) {
+		t.Fatalf("got output: %s, but expected it to contain: %s", buf.String(), expected)
+	}
+}
+
+func Test_default_sdid_order(t *testing.T) {
+	sd := syslog.StructuredData{}
+	for _, id := range []string{"trace@32473", "timeQuality", "meta@32473", "origin"} {
+		sd.Element(id).Set("p", "v")
+	}
+
+	expected := []string{"meta@32473", "origin", "timeQuality", "trace@32473"}
+	if !reflect.DeepEqual(sd.Ids(), expected) {
+		t.Fatalf("got ids: %v, but expected: %v", sd.Ids(), expected)
+	}
+}
+
+func Test_custom_sdid_less(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("a").Set("p", "v")
+	sd.Element("b").Set("p", "v")
+
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.INFO, "hostname", "appName", "procid",
+		syslog.WithDefaultStructuredData(sd),
+		syslog.WithSDIDOrder(func(a, b string) bool { return a > b }),
+	)
+	w.Write([]byte("message"))
+
+	if !strings.Contains(buf.String(), ` [b p="v"][a p="v"] message`) {
+		t.Fatalf("non-expected output: %s", buf.String())
+	}
+	if sd.String() != `[a p="v"][b p="v"]` {
+		t.Fatalf("got string: %v, but expected the order of the writer not to affect it", sd.String())
+	}
+}
@@ -0,0 +1,91 @@
+package syslog
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"strconv"
+	"sync/atomic"
+)
+
+// WithSeverityClamp limits the severity of every message to the range
+// between min and max, compared by their numeric value. Because a
+// lower value means a higher severity, WithSeverityClamp(log_level.CRITICAL,
+// log_level.DEBUG) turns EMERGENCY and ALERT messages into CRITICAL
+// messages, and leaves all other severities untouched.
+func WithSeverityClamp(min, max log_level.Priority) Option {
+	return func(o *options) {
+		o.clamp = &severityRange{min, max}
+	}
+}
+
+type severityRange struct {
+	min, max log_level.Priority
+}
+
+func (o *options) clampSeverity(severity log_level.Priority) log_level.Priority {
+	if o.clamp == nil {
+		return severity
+	}
+	if severity < o.clamp.min {
+		return o.clamp.min
+	}
+	if severity > o.clamp.max {
+		return o.clamp.max
+	}
+	return severity
+}
+
+// WithNumericPriorityFields adds the numeric severity and facility of
+// every message as the sev and fac params of the log element, for
+// consumers that group by the numeric codes instead of parsing the
+// PRI.
+func WithNumericPriorityFields() Option {
+	return func(o *options) {
+		o.numericPriority = true
+	}
+}
+
+func setNumericPriority(sd StructuredData, pri log_level.Priority) {
+	sd.Element("log").
+		Set("sev", strconv.Itoa(int(pri.Severity()))).
+		Set("fac", strconv.Itoa(int(pri>>3)))
+}
+
+// WithSDForSeverity adds sd to every message with a severity at or
+// above threshold, so WithSDForSeverity(log_level.ERROR, sd) adds it
+// to ERROR, CRITICAL, ALERT and EMERGENCY messages only. Params that
+// are already present in the structured data of the message are not
+// overwritten.
+func WithSDForSeverity(threshold log_level.Priority, sd StructuredData) Option {
+	return func(o *options) {
+		o.severitySD = append(o.severitySD, severitySD{threshold, sd})
+	}
+}
+
+type severitySD struct {
+	threshold log_level.Priority
+	sd        StructuredData
+}
+
+// WithMinSeverity drops every message that is less severe than min.
+// Because a lower value means a higher severity, WithMinSeverity(log_level.NOTICE)
+// drops INFO and DEBUG messages and keeps NOTICE and everything more
+// severe. Writers judge a message that is already formatted by its
+// own PRI. The threshold of a logger can be changed later with
+// SetMinSeverity.
+func WithMinSeverity(min log_level.Priority) Option {
+	return func(o *options) {
+		o.minSeverity = min.Severity()
+	}
+}
+
+// SetMinSeverity changes the threshold set by WithMinSeverity. It is
+// safe to call while messages are logged.
+func (l *WriterLogger) SetMinSeverity(min log_level.Priority) {
+	atomic.StoreInt32(&l.minSeverity, int32(min.Severity()))
+}
+
+// enabled reports whether a message with the given severity passes
+// the threshold set by WithMinSeverity.
+func (l *WriterLogger) enabled(severity log_level.Priority) bool {
+	return int32(severity.Severity()) <= atomic.LoadInt32(&l.minSeverity)
+}
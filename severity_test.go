@@ -0,0 +1,141 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+func Test_severity_clamp(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithSeverityClamp(log_level.CRITICAL, log_level.INFO))
+
+	l.Log(log_level.EMERGENCY, "", nil, "emergency")
+	l.Log(log_level.ERROR, "", nil, "error")
+	l.Log(log_level.DEBUG, "", nil, "debug")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expectedPrefixes := []string{"<10>1", "<11>1", "<14>1"}
+	for i, prefix := range expectedPrefixes {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Fatalf("got: %s, but expected prefix: %s", lines[i], prefix)
+		}
+	}
+}
+
+func Test_severity_clamp_writer(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.LOCAL0|log_level.EMERGENCY, "laptop", "testapp", "123",
+		syslog.WithSeverityClamp(log_level.CRITICAL, log_level.DEBUG))
+	w.Write([]byte("message"))
+
+	if !strings.HasPrefix(buf.String(), "<130>1") {
+		t.Fatalf("non-expected prefix: %s", buf.String())
+	}
+}
+
+func Test_numeric_priority_fields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.LOCAL4, "hostname", "appName", "procid", syslog.WithNumericPriorityFields())
+	l.Log(log_level.WARNING, "", nil, "warning")
+
+	// LOCAL4 is facility 20 and WARNING severity 4: 20*8+4 = 164
+	if !strings.HasPrefix(buf.String(), "<164>1") {
+		t.Fatalf("non-expected prefix: %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), `[log fac="20" sev="4"]`) {
+		t.Fatalf("missing numeric priority fields: %s", buf.String())
+	}
+}
+
+func Test_sd_for_severity(t *testing.T) {
+	dump := syslog.StructuredData{}
+	dump.Element("request").Set("body", "{}")
+
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid",
+		syslog.WithSDForSeverity(log_level.ERROR, dump))
+
+	l.Log(log_level.ERROR, "", nil, "error")
+	l.Log(log_level.INFO, "", nil, "info")
+
+	lines := strings.Split(buf.String(), "\n")
+	if !strings.HasSuffix(lines[0], ` - [request body="{}"] error`) {
+		t.Fatalf("expected structured data on error: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], " - - info") {
+		t.Fatalf("expected no structured data on info: %s", lines[1])
+	}
+}
+
+func Test_min_severity(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithMinSeverity(log_level.NOTICE))
+
+	l.Log(log_level.INFO, "", nil, "dropped")
+	if buf.Len() != 0 {
+		t.Fatalf("got output: %q, but expected INFO to be dropped", buf.String())
+	}
+
+	l.Log(log_level.NOTICE, "", nil, "kept")
+	if !strings.HasSuffix(buf.String(), " kept\n") {
+		t.Fatalf("got output: %q, but expected NOTICE to be kept", buf.String())
+	}
+
+	buf.Reset()
+	l.Log(log_level.ERROR, "", nil, "kept")
+	if !strings.HasSuffix(buf.String(), " kept\n") {
+		t.Fatalf("got output: %q, but expected ERROR to be kept", buf.String())
+	}
+}
+
+func Test_set_min_severity(t *testing.T) {
+	var dropped int
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithHooks(nil,
+		func(severity log_level.Priority, n int, err error) {
+			if err == syslog.ErrDropped {
+				dropped++
+			}
+		},
+	))
+
+	l.Log(log_level.DEBUG, "", nil, "kept")
+	if buf.Len() == 0 {
+		t.Fatalf("expected DEBUG to be kept without a threshold")
+	}
+
+	buf.Reset()
+	l.SetMinSeverity(log_level.WARNING)
+	l.Log(log_level.NOTICE, "", nil, "dropped")
+	l.Log(log_level.WARNING, "", nil, "kept")
+	if buf.String() == "" || strings.Contains(buf.String(), "dropped") {
+		t.Fatalf("got output: %q, but expected only the WARNING message", buf.String())
+	}
+	if dropped != 1 {
+		t.Fatalf("got dropped: %v, but expected: 1", dropped)
+	}
+}
+
+func Test_min_severity_writer(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.INFO, "hostname", "appName", "procid",
+		syslog.WithMinSeverity(log_level.NOTICE))
+
+	if n, err := w.Write([]byte("dropped")); n != len("dropped") || err != nil {
+		t.Fatalf("got n: %v and error: %v, but expected the message to be dropped silently", n, err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("got output: %q, but expected INFO to be dropped", buf.String())
+	}
+
+	// a formatted message is judged by its own PRI
+	w.Write([]byte("<12>1 2021-03-04T05:06:07Z host app - - - forwarded warning"))
+	w.Write([]byte("<15>1 2021-03-04T05:06:07Z host app - - - forwarded debug"))
+	if !strings.Contains(buf.String(), "forwarded warning") || strings.Contains(buf.String(), "forwarded debug") {
+		t.Fatalf("got output: %q, but expected only the forwarded WARNING message", buf.String())
+	}
+}
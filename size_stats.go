@@ -0,0 +1,107 @@
+package syslog
+
+import "math/bits"
+
+// SizeStats summarizes the sizes in bytes of the frames written by a
+// logger. The percentiles are approximations: they are accurate to
+// within 12.5% of the actual value.
+type SizeStats struct {
+	Count uint64
+	Min   int
+	Max   int
+	P50   int
+	P90   int
+	P99   int
+}
+
+// SizeStats returns statistics of the sizes of the frames written
+// since the logger was created. Frames that are dropped are not
+// counted.
+func (l *WriterLogger) SizeStats() SizeStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.sizes.stats()
+}
+
+// subBuckets is the number of buckets every power of two is divided
+// into, which bounds the relative error of a percentile to
+// 1/subBuckets.
+const (
+	subBucketBits = 3
+	subBuckets    = 1 << subBucketBits
+)
+
+// sizeHistogram counts sizes in buckets that grow exponentially, so
+// it uses a small, fixed amount of memory for any number of sizes.
+// Sizes below subBuckets have a bucket of their own.
+type sizeHistogram struct {
+	count    uint64
+	min, max int
+	buckets  [subBuckets * (bits.UintSize - subBucketBits + 1)]uint64
+}
+
+func (h *sizeHistogram) add(n int) {
+	if h.count == 0 || n < h.min {
+		h.min = n
+	}
+	if n > h.max {
+		h.max = n
+	}
+	h.count++
+	h.buckets[bucketOf(n)]++
+}
+
+func (h *sizeHistogram) stats() SizeStats {
+	return SizeStats{
+		Count: h.count,
+		Min:   h.min,
+		Max:   h.max,
+		P50:   h.percentile(0.50),
+		P90:   h.percentile(0.90),
+		P99:   h.percentile(0.99),
+	}
+}
+
+// percentile returns the upper bound of the bucket that holds the
+// size at percentile p, limited to the range of sizes seen.
+func (h *sizeHistogram) percentile(p float64) int {
+	if h.count == 0 {
+		return 0
+	}
+	rank := uint64(p*float64(h.count) + 0.5)
+	if rank == 0 {
+		rank = 1
+	}
+	var seen uint64
+	for i, c := range h.buckets {
+		seen += c
+		if seen >= rank {
+			n := bucketUpperBound(i)
+			if n > h.max {
+				n = h.max
+			}
+			if n < h.min {
+				n = h.min
+			}
+			return n
+		}
+	}
+	return h.max
+}
+
+func bucketOf(n int) int {
+	if n < subBuckets {
+		return n
+	}
+	shift := bits.Len(uint(n)) - 1 - subBucketBits
+	return subBuckets + shift*subBuckets + (n>>uint(shift))&(subBuckets-1)
+}
+
+func bucketUpperBound(i int) int {
+	if i < subBuckets {
+		return i
+	}
+	shift := uint((i - subBuckets) / subBuckets)
+	sub := (i - subBuckets) % subBuckets
+	return (subBuckets+sub+1)<<shift - 1
+}
@@ -0,0 +1,46 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+func Test_size_stats(t *testing.T) {
+	var sizes []int
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid")
+
+	for i := 1; i <= 100; i++ {
+		before := buf.Len()
+		l.Log(log_level.INFO, "", nil, strings.Repeat("x", i*10))
+		sizes = append(sizes, buf.Len()-before)
+	}
+
+	stats := l.SizeStats()
+	if stats.Count != 100 || stats.Min != sizes[0] || stats.Max != sizes[99] {
+		t.Fatalf("got stats: %+v, but expected count 100, min %d and max %d", stats, sizes[0], sizes[99])
+	}
+	for _, p := range []struct {
+		name     string
+		got      int
+		expected int
+	}{
+		{"p50", stats.P50, sizes[49]},
+		{"p90", stats.P90, sizes[89]},
+		{"p99", stats.P99, sizes[98]},
+	} {
+		if p.got < p.expected || float64(p.got) > float64(p.expected)*1.125 {
+			t.Fatalf("got %s: %d, but expected about: %d", p.name, p.got, p.expected)
+		}
+	}
+}
+
+func Test_size_stats_empty(t *testing.T) {
+	l := syslog.NewLogger(&bytes.Buffer{}, syslog.USER, "hostname", "appName", "procid")
+	if stats := l.SizeStats(); stats != (syslog.SizeStats{}) {
+		t.Fatalf("got stats: %+v, but expected zero values", stats)
+	}
+}
@@ -0,0 +1,139 @@
+//go:build go1.21
+// +build go1.21
+
+package syslog
+
+import (
+	"context"
+	"github.com/confetti-framework/syslog/log_level"
+	"io"
+	"log/slog"
+)
+
+// DefaultSlogSDID is the SD-ID of the element that holds the
+// attributes of slog records.
+const DefaultSlogSDID = "slog"
+
+// WithSlogSDID sets the SD-ID of the element that holds the attributes
+// of the records handled by a handler created by NewSlogHandler.
+func WithSlogSDID(id string) Option {
+	return func(o *options) {
+		o.slogSDID = id
+	}
+}
+
+// NewSlogHandler returns a slog.Handler that writes records as syslog
+// messages to w. The level of a record is mapped to a severity, its
+// message becomes the message of the syslog message and its attributes
+// become params of a single structured data element, see WithSlogSDID.
+// The attributes of a group are prefixed by the group name and a dot,
+// so the attribute id in the group req becomes the param req.id.
+// The returned Handler is safe for concurrent use by
+// multiple goroutines.
+func NewSlogHandler(w io.Writer, facility log_level.Priority, hostname, appName, procid string, opts ...Option) slog.Handler {
+	l := NewLogger(w, facility, hostname, appName, procid, opts...)
+	sdID := l.opts.slogSDID
+	if sdID == "" {
+		sdID = DefaultSlogSDID
+	}
+	return &slogHandler{l: l, sdID: sdID}
+}
+
+type slogHandler struct {
+	l      *WriterLogger
+	sdID   string
+	params []slogParam
+	prefix string
+}
+
+type slogParam struct {
+	name  string
+	value string
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.l.enabled(severityOfSlogLevel(level))
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	// copy the params of the handler, appending to them would share
+	// their spare capacity with concurrent calls
+	params := append(make([]slogParam, 0, len(h.params)+r.NumAttrs()), h.params...)
+	r.Attrs(func(a slog.Attr) bool {
+		params = appendSlogAttr(params, h.prefix, a)
+		return true
+	})
+
+	var sd StructuredData
+	if len(params) > 0 {
+		sd = StructuredData{}
+		elem := sd.Element(h.sdID)
+		for _, p := range params {
+			elem.Set(p.name, p.value)
+		}
+	}
+
+	return h.l.log(r.Time, h.l.facility, severityOfSlogLevel(r.Level), "", sd, []byte(r.Message))
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.params = append([]slogParam(nil), h.params...)
+	for _, a := range attrs {
+		c.params = appendSlogAttr(c.params, h.prefix, a)
+	}
+	return &c
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.prefix = h.prefix + name + "."
+	return &c
+}
+
+// appendSlogAttr appends the params for a, flattening groups.
+func appendSlogAttr(params []slogParam, prefix string, a slog.Attr) []slogParam {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			params = appendSlogAttr(params, prefix, ga)
+		}
+		return params
+	}
+	if a.Key == "" {
+		return params
+	}
+	return append(params, slogParam{prefix + a.Key, v.String()})
+}
+
+// severityOfSlogLevel maps a slog level to a syslog severity. Levels
+// between the levels defined by slog map to the next lower severity,
+// except for the range between info and warn, which maps to notice.
+// Levels above error map to critical, alert and emergency in steps
+// of 4.
+func severityOfSlogLevel(level slog.Level) log_level.Priority {
+	switch {
+	case level < slog.LevelInfo:
+		return log_level.DEBUG
+	case level == slog.LevelInfo:
+		return log_level.INFO
+	case level < slog.LevelWarn:
+		return log_level.NOTICE
+	case level < slog.LevelError:
+		return log_level.WARNING
+	case level < slog.LevelError+4:
+		return log_level.ERROR
+	case level < slog.LevelError+8:
+		return log_level.CRITICAL
+	case level < slog.LevelError+12:
+		return log_level.ALERT
+	default:
+		return log_level.EMERGENCY
+	}
+}
@@ -0,0 +1,79 @@
+//go:build go1.21
+// +build go1.21
+
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func Test_slog_handler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := syslog.NewSlogHandler(buf, syslog.USER, "hostname", "appName", "procid")
+	logger := slog.New(h).With("service", "auth").WithGroup("req")
+
+	logger.Warn("login failed", "user", "bob", slog.Group("client", "ip", "10.0.0.1"))
+
+	if !strings.HasPrefix(buf.String(), "<12>1 ") {
+		t.Fatalf("non-expected prefix: %s", buf.String())
+	}
+	expectedSuffix := ` - [slog req.client.ip="10.0.0.1" req.user="bob" service="auth"] login failed` + "\n"
+	if !strings.HasSuffix(buf.String(), expectedSuffix) {
+		t.Fatalf("got: %s, but expected suffix: %s", buf.String(), expectedSuffix)
+	}
+}
+
+func Test_slog_handler_levels_and_sd_id(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := syslog.NewSlogHandler(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithSlogSDID("attrs@32473"))
+	logger := slog.New(h)
+
+	logger.Debug("debug")
+	logger.Info("info", "n", 1)
+	logger.Error("error")
+
+	lines := strings.Split(buf.String(), "\n")
+	for i, expected := range []string{"<15>1 ", "<14>1 ", "<11>1 "} {
+		if !strings.HasPrefix(lines[i], expected) {
+			t.Fatalf("got: %s, but expected prefix: %s", lines[i], expected)
+		}
+	}
+	if !strings.HasSuffix(lines[1], ` - [attrs@32473 n="1"] info`) {
+		t.Fatalf("non-expected message: %s", lines[1])
+	}
+}
+
+func Test_slog_handler_concurrent_with_attrs(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := syslog.NewSlogHandler(buf, syslog.USER, "hostname", "appName", "procid")
+	// three attrs leave spare capacity in the params of the handler
+	logger := slog.New(h.WithAttrs([]slog.Attr{slog.String("a", "1"), slog.String("b", "2"), slog.String("c", "3")}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			logger.Info("message "+strconv.Itoa(i), "n", strconv.Itoa(i))
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 50 {
+		t.Fatalf("got %d lines, but expected: %d", len(lines), 50)
+	}
+	for _, line := range lines {
+		i := line[strings.LastIndex(line, " ")+1:]
+		expected := `[slog a="1" b="2" c="3" n="` + i + `"] message ` + i
+		if !strings.HasSuffix(line, expected) {
+			t.Fatalf("got: %s, but expected suffix: %s", line, expected)
+		}
+	}
+}
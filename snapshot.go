@@ -0,0 +1,49 @@
+package syslog
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"sync/atomic"
+)
+
+// Config is the configuration of a logger at the time of a Snapshot:
+// its options, formatters and minimum severity. It is an immutable
+// value that can only be used to Restore a logger.
+type Config struct {
+	opts        options
+	formatters  []Formatter
+	minSeverity log_level.Priority
+}
+
+// Snapshot returns the current configuration of the logger.
+func (l *WriterLogger) Snapshot() Config {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	c := Config{
+		opts:        l.opts,
+		formatters:  make([]Formatter, len(l.sinks)),
+		minSeverity: log_level.Priority(atomic.LoadInt32(&l.minSeverity)),
+	}
+	for i, s := range l.sinks {
+		c.formatters[i] = s.f
+	}
+	return c
+}
+
+// Restore replaces the configuration of the logger by a configuration
+// returned by Snapshot, for example to undo changes made by a test.
+// The destinations of the logger are not changed. Restoring a Config
+// of a logger with other destinations only restores the formatters of
+// the destinations both loggers have.
+func (l *WriterLogger) Restore(c Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.opts = c.opts
+	for i := range l.sinks {
+		if i < len(c.formatters) {
+			l.sinks[i].f = c.formatters[i]
+		}
+	}
+	atomic.StoreInt32(&l.minSeverity, int32(c.minSeverity))
+}
@@ -0,0 +1,50 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+func Test_snapshot_restore(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid")
+	l.SetMinSeverity(log_level.WARNING)
+
+	config := l.Snapshot()
+	l.SetMinSeverity(log_level.DEBUG)
+	l.Log(log_level.DEBUG, "", nil, "changed")
+	if !strings.Contains(buf.String(), "changed") {
+		t.Fatalf("got: %q, but expected the debug message", buf.String())
+	}
+
+	buf.Reset()
+	l.Restore(config)
+	l.Log(log_level.DEBUG, "", nil, "restored")
+	l.Log(log_level.WARNING, "", nil, "warning")
+
+	if strings.Contains(buf.String(), "restored") {
+		t.Fatalf("got: %q, but expected the debug message to be dropped", buf.String())
+	}
+	if !strings.Contains(buf.String(), "warning") {
+		t.Fatalf("got: %q, but expected the warning message", buf.String())
+	}
+}
+
+func Test_snapshot_is_immutable(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithMinSeverity(log_level.ERROR))
+
+	config := l.Snapshot()
+	l.SetMinSeverity(log_level.DEBUG)
+	l.Restore(config)
+	l.SetMinSeverity(log_level.INFO)
+	l.Restore(config)
+
+	l.Log(log_level.WARNING, "", nil, "warning")
+	if buf.Len() != 0 {
+		t.Fatalf("got: %q, but expected no output", buf.String())
+	}
+}
@@ -0,0 +1,34 @@
+package syslog
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog/log_level"
+	"log"
+)
+
+// StdLogger returns a *log.Logger from the standard library that
+// logs every line it is given with l at the given severity and with
+// the given msgId. Output that spans multiple lines is logged as one
+// message per line. Output returns the error of the first message
+// that failed to be logged.
+func StdLogger(l Logger, severity log_level.Priority, msgId string) *log.Logger {
+	return log.New(&stdLoggerWriter{l, severity, msgId}, "", 0)
+}
+
+type stdLoggerWriter struct {
+	l        Logger
+	severity log_level.Priority
+	msgId    string
+}
+
+func (w *stdLoggerWriter) Write(d []byte) (int, error) {
+	for _, line := range bytes.Split(bytes.TrimRight(d, "\n"), nl) {
+		if len(line) == 0 {
+			continue
+		}
+		if err := w.l.Log(w.severity, w.msgId, nil, "%s", line); err != nil {
+			return 0, err
+		}
+	}
+	return len(d), nil
+}
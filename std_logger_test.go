@@ -0,0 +1,37 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+func Test_std_logger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid")
+
+	std := syslog.StdLogger(l, log_level.WARNING, "StdLog")
+	std.Print("first line\nsecond line")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d messages, but expected 2: %s", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "<12>1") || !strings.HasSuffix(lines[0], " StdLog - first line") {
+		t.Fatalf("non-expected message: %s", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "<12>1") || !strings.HasSuffix(lines[1], " StdLog - second line") {
+		t.Fatalf("non-expected message: %s", lines[1])
+	}
+}
+
+func Test_std_logger_reports_failed_writes(t *testing.T) {
+	l := syslog.NewLogger(failingWriter{}, syslog.USER, "hostname", "appName", "procid")
+
+	std := syslog.StdLogger(l, log_level.WARNING, "StdLog")
+	if err := std.Output(1, "message"); err == nil {
+		t.Fatalf("expected the error of the failed write")
+	}
+}
@@ -0,0 +1,45 @@
+package syslog
+
+import (
+	"fmt"
+	"github.com/confetti-framework/syslog/log_level"
+)
+
+// WithStrictPriority rejects priorities outside the range defined by
+// RFC 5424, which are otherwise written as they are, such as a
+// facility that includes a severity or a severity that includes a
+// facility, for example because two severities were combined instead
+// of a facility and a severity. Dial, DialTLS and DialLocal return an
+// error for an invalid priority. Writer.Write and Logger.Log return
+// an error for a message with an invalid priority, which is not
+// written.
+func WithStrictPriority() Option {
+	return func(o *options) {
+		o.strictPriority = true
+	}
+}
+
+// checkPriority returns an error for an invalid priority in strict
+// mode.
+func (o *options) checkPriority(pri log_level.Priority) error {
+	if !o.strictPriority || pri.Valid() {
+		return nil
+	}
+	return fmt.Errorf("syslog: invalid priority %d", pri)
+}
+
+// checkFacilityAndSeverity returns an error in strict mode for a
+// facility that is invalid or includes a severity, and for a severity
+// that is not in the range of 0 to 7.
+func (o *options) checkFacilityAndSeverity(facility, severity log_level.Priority) error {
+	if !o.strictPriority {
+		return nil
+	}
+	if !facility.Valid() || facility.Severity() != 0 {
+		return fmt.Errorf("syslog: invalid facility %d", facility)
+	}
+	if severity < log_level.EMERGENCY || severity > log_level.DEBUG {
+		return fmt.Errorf("syslog: invalid severity %d", severity)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"testing"
+)
+
+func Test_strict_priority_logger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	// a severity passed as the facility
+	l := syslog.NewLogger(buf, log_level.ERROR, "hostname", "appName", "procid", syslog.WithStrictPriority())
+
+	err := l.Log(log_level.INFO, "", nil, "message")
+	if err == nil || err.Error() != "syslog: invalid facility 3" {
+		t.Fatalf("got error: %v, but expected: syslog: invalid facility 3", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("got: %q, but expected no output", buf.String())
+	}
+
+	l = syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithStrictPriority())
+	err = l.Log(syslog.LOCAL0|log_level.INFO, "", nil, "message")
+	if err == nil || err.Error() != "syslog: invalid severity 134" {
+		t.Fatalf("got error: %v, but expected: syslog: invalid severity 134", err)
+	}
+	if err := l.Log(log_level.INFO, "", nil, "message"); err != nil || buf.Len() == 0 {
+		t.Fatalf("got error: %v, but expected the message to be written", err)
+	}
+}
+
+func Test_strict_priority_writer(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, 24<<3, "hostname", "appName", "procid", syslog.WithStrictPriority())
+
+	if _, err := w.Write([]byte("message")); err == nil || err.Error() != "syslog: invalid priority 192" {
+		t.Fatalf("got error: %v, but expected: syslog: invalid priority 192", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("got: %q, but expected no output", buf.String())
+	}
+}
+
+func Test_strict_priority_dial(t *testing.T) {
+	_, err := syslog.Dial("udp", "127.0.0.1:514", 24<<3, "hostname", "appName", "procid", syslog.WithStrictPriority())
+	if err == nil || err.Error() != "syslog: invalid priority 192" {
+		t.Fatalf("got error: %v, but expected: syslog: invalid priority 192", err)
+	}
+}
+
+func Test_lenient_priority_by_default(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, log_level.ERROR, "hostname", "appName", "procid")
+
+	if err := l.Log(log_level.INFO, "", nil, "message"); err != nil || buf.Len() == 0 {
+		t.Fatalf("got error: %v, but expected the message to be written", err)
+	}
+}
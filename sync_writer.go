@@ -0,0 +1,50 @@
+package syslog
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"io"
+	"sync"
+)
+
+// SyncWriter is a Writer that is safe for concurrent use by multiple
+// goroutines.
+type SyncWriter struct {
+	mu sync.Mutex
+	w  *Writer
+}
+
+// NewSyncWriter returns a Writer like NewWriter that serializes its
+// writes, so every message is written as a whole also when many
+// goroutines write to it, for example through a log.Logger:
+//
+//	log.New(syslog.NewSyncWriter(out, syslog.USER|log_level.INFO, "hostname", "appName", "procid"), "", 0)
+func NewSyncWriter(out io.Writer, pri log_level.Priority, hostname, appName, procid string, opts ...Option) *SyncWriter {
+	return &SyncWriter{w: NewWriter(out, pri, hostname, appName, procid, opts...)}
+}
+
+// Write generates and writes a syslog message, see Writer.Write.
+func (s *SyncWriter) Write(d []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(d)
+}
+
+// Flush writes any buffered messages, see Writer.Flush.
+func (s *SyncWriter) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Flush()
+}
+
+// Close flushes any buffered messages and closes the underlying
+// io.Writer, see Writer.Close.
+func (s *SyncWriter) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Close()
+}
+
+// LastError returns the error of the last write, see Writer.LastError.
+func (s *SyncWriter) LastError() error {
+	return s.w.LastError()
+}
@@ -0,0 +1,56 @@
+package syslog_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func Test_sync_writer_concurrent_writes(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewSyncWriter(buf, syslog.USER|log_level.INFO, "hostname", "appName", "procid",
+		syslog.WithWriteBuffer(256))
+	logger := log.New(w, "", 0)
+
+	const goroutines, messages = 20, 25
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < messages; i++ {
+				logger.Printf("goroutine %d message %d %s", g, i, strings.Repeat("x", 50))
+			}
+		}(g)
+	}
+	wg.Wait()
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != goroutines*messages {
+		t.Fatalf("got %d lines, but expected: %d", len(lines), goroutines*messages)
+	}
+	seen := map[string]bool{}
+	for _, line := range lines {
+		m, err := syslog.Parse([]byte(line))
+		if err != nil {
+			t.Fatalf("got error: %v for line: %q", err, line)
+		}
+		var g, i int
+		var x string
+		if _, err := fmt.Sscanf(string(m.Msg), "goroutine %d message %d %s", &g, &i, &x); err != nil || x != strings.Repeat("x", 50) {
+			t.Fatalf("got interleaved message: %q", m.Msg)
+		}
+		seen[string(m.Msg)] = true
+	}
+	if len(seen) != goroutines*messages {
+		t.Fatalf("got %d distinct messages, but expected: %d", len(seen), goroutines*messages)
+	}
+}
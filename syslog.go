@@ -7,14 +7,16 @@
 package syslog
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"github.com/confetti-framework/syslog/log_level"
 	"io"
 	"sort"
-	"strings"
+	"strconv"
 	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 type Facility = log_level.Priority
@@ -53,57 +55,160 @@ const (
 const version = 1 // defined in RFC 5424.
 
 // NewWriter wrappes another io.Writer and returns a new
-// io.Writer that generates syslog messages as defined
+// Writer that generates syslog messages as defined
 // in RFC 5424 and writes them to the given io.Writer.
-// The returned io.Writer is NOT safe for concurrent use
+// The returned Writer is NOT safe for concurrent use
 // by multiple goroutines.
-func NewWriter(out io.Writer, pri log_level.Priority, hostname, appName, procid string) io.Writer {
-	return &writer{
-		out,
-		pri,
-		hostname,
-		appName,
-		procid,
+//
+// The options WithBatch, WithCompressRotated and WithRetention don't
+// apply to it, every Write returns an error when one is given.
+func NewWriter(out io.Writer, pri log_level.Priority, hostname, appName, procid string, opts ...Option) *Writer {
+	o := newOptions(opts)
+	w := newWriter(out, pri, hostname, appName, procid, o)
+	w.optErr = o.unsupported(messageTarget)
+	return w
+}
+
+func newWriter(out io.Writer, pri log_level.Priority, hostname, appName, procid string, o options) *Writer {
+	hostname, procid = o.detect(hostname, procid)
+	w := &Writer{
+		out:      out,
+		pri:      pri,
+		hostname: hostname,
+		appName:  appName,
+		procid:   procid,
+		opts:     o,
 	}
+	if w.opts.writeBuffer > 0 {
+		w.buf = bufio.NewWriterSize(out, w.opts.writeBuffer)
+	}
+	w.capture = captureSink()
+	return w
 }
 
 // Writer generates syslog messages as defined in RFC 5424.
-type writer struct {
+type Writer struct {
 	out      io.Writer
+	buf      *bufio.Writer
 	pri      log_level.Priority
 	hostname string
 	appName  string
 	procid   string
+	opts     options
+	lastErr  lastError
+	capture  *TestSink
+
+	// optErr reports an option that doesn't apply to the Writer.
+	optErr error
 }
 
 var nl = []byte{'\n'}
 
 // Write generates and writes a syslog message to the
 // underlying io.Writer.
-func (w *writer) Write(d []byte) (int, error) {
+func (w *Writer) Write(d []byte) (int, error) {
 	if len(d) == 0 {
 		return 0, nil
 	}
 
-	// don't format a syslog message
-	if d[0] != '<' {
-		d = formatSyslog(
-			w.pri,
-			time.Now(),
-			"",
+	start := time.Now()
+	severity := w.opts.clampSeverity(w.pri.Severity())
+	w.opts.beforeWrite(severity, w.opts.msgID)
+	n, err := w.write(severity, d)
+	if err == nil {
+		w.opts.emitLatency.record(start)
+	}
+	w.opts.afterWrite(severity, n, err)
+	w.lastErr.store(err)
+	if err == ErrDropped {
+		return len(d), nil
+	}
+	return n, err
+}
+
+func (w *Writer) write(severity log_level.Priority, d []byte) (int, error) {
+	if w.optErr != nil {
+		return 0, w.optErr
+	}
+	if pri, ok := framePRI(d); ok && pri.Severity() > w.opts.minSeverity ||
+		!ok && severity > w.opts.minSeverity {
+		return 0, ErrDropped
+	}
+
+	// don't format a syslog message, but do format a message that
+	// merely starts with '<', such as "<nil>"
+	if !hasPRI(d) {
+		if err := w.opts.checkPriority(w.pri); err != nil {
+			return 0, err
+		}
+		if err := w.opts.allowApp(w.appName); err != nil {
+			return 0, err
+		}
+		pri := w.pri.Facility() | severity
+		now := w.opts.timestamp(w.opts.clock())
+		sd := w.opts.structuredData(nil, pri, now)
+		if err := w.opts.validateSchema(sd); err != nil {
+			return 0, err
+		}
+		buf := getBuffer()
+		defer putBuffer(buf)
+		d = w.opts.format(
+			buf,
+			w.opts.formatter,
+			pri,
+			now,
 			w.hostname,
 			w.appName,
 			w.procid,
-			"",
-			nil,
+			w.opts.msgID,
+			sd,
 			d)
+	} else {
+		if err := w.opts.allowApp(frameAppName(d)); err != nil {
+			return 0, err
+		}
+		d = w.opts.reframe(d)
 	}
 
-	n, err := w.out.Write(d)
-	if d[len(d)-1] != '\n' && err == nil {
-		w.out.Write(nl)
+	// a message is always written as a whole, including the newline
+	n := len(d)
+	if d[n-1] != '\n' {
+		d = append(d[:n:n], '\n')
+	}
+	d = w.opts.delimit(d)
+
+	if err := w.opts.limitBytes(len(d)); err != nil {
+		return 0, err
+	}
+
+	var written int
+	var err error
+	if w.buf != nil {
+		written, err = w.writeBuffered(d)
+	} else {
+		written, err = w.out.Write(d)
+	}
+	if err == nil && w.capture != nil {
+		w.capture.Write(d)
+	}
+	if written > n {
+		written = n
+	}
+	return written, err
+}
+
+// WithCompactNilValues omits the trailing header fields that hold
+// the NILVALUE, so a message without hostname, app name, procid,
+// msgid and structured data is written as "<PRI>1 TIMESTAMP MSG".
+//
+// RFC 5424 requires every header field to be present, so the
+// resulting messages are only understood by collectors that tolerate
+// the missing fields. Fields followed by a non-nil field are always
+// written.
+func WithCompactNilValues() Option {
+	return func(o *options) {
+		o.compactNil = true
 	}
-	return n, err
 }
 
 const rfc3339Milli = "2006-01-02T15:04:05.999-07:00"
@@ -112,46 +217,88 @@ func formatSyslog(
 	pri log_level.Priority,
 	timestamp time.Time,
 	timeFormat string,
+	compact bool,
+	bom bool,
 	hostname string,
 	appName string,
 	procid string,
 	msgid string,
 	structData StructuredData,
+	order sdOrder,
 	msg []byte,
 ) []byte {
+	buf := bytes.NewBuffer(make([]byte, 0, len(msg)+128))
+	writeSyslog(buf, pri, timestamp, timeFormat, compact, bom, hostname, appName, procid, msgid, structData, order, msg)
+	return buf.Bytes()
+}
+
+// writeSyslog formats a message like formatSyslog, but appends it to
+// buf.
+func writeSyslog(
+	buf *bytes.Buffer,
+	pri log_level.Priority,
+	timestamp time.Time,
+	timeFormat string,
+	compact bool,
+	bom bool,
+	hostname string,
+	appName string,
+	procid string,
+	msgid string,
+	structData StructuredData,
+	order sdOrder,
+	msg []byte,
+) {
 	if timeFormat == "" {
 		timeFormat = rfc3339Milli
 	}
 
-	ts := timestamp.Format(timeFormat)
-	hostname = defaultIfEmpty(hostname, "-")
-	appName = defaultIfEmpty(appName, "-")
-	procid = defaultIfEmpty(procid, "-")
-	msgid = defaultIfEmpty(msgid, "-")
+	hostname = defaultIfEmpty(sanitizeHeaderField(hostname, maxHostnameLength), "-")
+	appName = defaultIfEmpty(sanitizeHeaderField(appName, maxAppNameLength), "-")
+	procid = defaultIfEmpty(sanitizeHeaderField(procid, maxProcIDLength), "-")
+	msgid = defaultIfEmpty(sanitizeHeaderField(msgid, maxMsgIDLength), "-")
 
-	sd := ""
-	if structData != nil {
-		sd = structData.String()
+	fields := [...]string{hostname, appName, procid, msgid}
+	n := len(fields)
+	hasSD := !structData.Empty()
+	if compact && !hasSD {
+		n = len(trimNilValues(fields[:]))
 	}
-	sd = defaultIfEmpty(sd, "-")
 
-	buf := &bytes.Buffer{}
-	fmt.Fprintf(buf, "<%d>%d %s %s %s %s %s %s ",
-		pri,
-		version,
-		ts,
-		hostname,
-		appName,
-		procid,
-		msgid,
-		sd,
-	)
+	var scratch [64]byte
+	buf.WriteByte('<')
+	buf.Write(strconv.AppendInt(scratch[:0], int64(pri), 10))
+	buf.WriteString(">")
+	buf.Write(strconv.AppendInt(scratch[:0], version, 10))
+	buf.WriteByte(' ')
+	buf.Write(timestamp.AppendFormat(scratch[:0], timeFormat))
+	buf.WriteByte(' ')
+	for _, field := range fields[:n] {
+		buf.WriteString(field)
+		buf.WriteByte(' ')
+	}
+	if hasSD {
+		structData.writeTo(buf, order)
+		buf.WriteByte(' ')
+	} else if !compact {
+		buf.WriteString("- ")
+	}
+	if bom && len(msg) > 0 && utf8.Valid(msg) {
+		buf.WriteString(utf8BOM)
+	}
 	buf.Write(msg)
 
 	if len(msg) == 0 || msg[len(msg)-1] != '\n' {
 		buf.WriteByte('\n')
 	}
-	return buf.Bytes()
+}
+
+// trimNilValues removes the trailing NILVALUE fields.
+func trimNilValues(fields []string) []string {
+	for len(fields) > 0 && fields[len(fields)-1] == "-" {
+		fields = fields[:len(fields)-1]
+	}
+	return fields
 }
 
 func defaultIfEmpty(s, def string) string {
@@ -164,49 +311,194 @@ func defaultIfEmpty(s, def string) string {
 // Logger generates syslog messages.
 type Logger interface {
 
-	// Log generates a syslog message.
-	Log(severity log_level.Priority, msgId string, sd StructuredData, msgFormat string, a ...interface{})
+	// Log generates a syslog message. It returns the error of
+	// writing the message, a message that is dropped by a filter is
+	// not an error.
+	Log(severity log_level.Priority, msgId string, sd StructuredData, msgFormat string, a ...interface{}) error
 }
 
 // NewLogger returns a new syslog logger that writes to
 // the specified io.Writer.
 // The returned Logger is safe for concurrent use by
 // multiple goroutines.
-func NewLogger(w io.Writer, facility log_level.Priority, hostname, appName, procid string) Logger {
-	return &logger{
-		sync.Mutex{},
-		w,
-		facility,
-		hostname,
-		appName,
-		procid,
+//
+// NewLogger is equivalent to New with the options WithFacility,
+// WithHostname, WithAppName and WithProcID.
+func NewLogger(w io.Writer, facility log_level.Priority, hostname, appName, procid string, opts ...Option) *WriterLogger {
+	return New(w, append([]Option{
+		WithFacility(facility),
+		WithHostname(hostname),
+		WithAppName(appName),
+		WithProcID(procid),
+	}, opts...)...)
+}
+
+// New returns a new syslog logger that writes to the specified
+// io.Writer, configured by options only:
+//
+//	l := syslog.New(os.Stderr,
+//		syslog.WithFacility(syslog.LOCAL0),
+//		syslog.WithAppName("api"),
+//		syslog.WithMinSeverity(log_level.INFO),
+//	)
+//
+// Without WithFacility the messages have the USER facility, header
+// fields without an option are written as the NILVALUE.
+// The returned Logger is safe for concurrent use by
+// multiple goroutines.
+func New(w io.Writer, opts ...Option) *WriterLogger {
+	o := newOptions(opts)
+	hostname, procid := o.detect(o.hostname, o.procid)
+	return &WriterLogger{
+		sinks:       []sink{{w, o.formatter}},
+		facility:    o.facility,
+		hostname:    hostname,
+		appName:     o.appName,
+		procid:      procid,
+		opts:        o,
+		minSeverity: int32(o.minSeverity),
+		capture:     captureSink(),
+		optErr:      o.unsupported(messageTarget),
 	}
 }
 
-type logger struct {
+// WriterLogger is a Logger that generates syslog messages and writes
+// them to one or more io.Writers.
+type WriterLogger struct {
 	mu       sync.Mutex
-	w        io.Writer
+	sinks    []sink
 	facility log_level.Priority
 	hostname string
 	appName  string
 	procid   string
+	opts     options
+	lastErr  lastError
+	sizes    sizeHistogram
+	capture  *TestSink
+
+	// minSeverity is accessed atomically, so it can be changed while
+	// messages are logged.
+	minSeverity int32
+
+	// optErr reports an option that doesn't apply to a logger.
+	optErr error
+}
+
+// sink is a destination of a logger together with the formatter of
+// the messages written to it.
+type sink struct {
+	w io.Writer
+	f Formatter
+}
+
+// Log generates a syslog message.
+func (l *WriterLogger) Log(severity log_level.Priority, msgId string, sd StructuredData, msgFormat string, a ...interface{}) error {
+	return l.LogAt(time.Time{}, severity, msgId, sd, msgFormat, a...)
+}
+
+// LogAt generates a syslog message with the given timestamp instead of
+// the current time, for example to replay events that carry the time
+// at which they occurred. A zero timestamp is replaced by the current
+// time, as for Log.
+func (l *WriterLogger) LogAt(timestamp time.Time, severity log_level.Priority, msgId string, sd StructuredData, msgFormat string, a ...interface{}) error {
+	return l.logf(timestamp, l.facility, severity, msgId, sd, msgFormat, a...)
+}
+
+// LogWithFacility generates a syslog message with the given facility
+// instead of the facility of the logger, so a single logger can, for
+// example, log security events with the AUTH facility and other
+// messages with the DAEMON facility.
+func (l *WriterLogger) LogWithFacility(facility, severity log_level.Priority, msgId string, sd StructuredData, msgFormat string, a ...interface{}) error {
+	return l.logf(time.Time{}, facility, severity, msgId, sd, msgFormat, a...)
 }
 
-func (l *logger) Log(severity log_level.Priority, msgId string, sd StructuredData, msgFormat string, a ...interface{}) {
+func (l *WriterLogger) logf(timestamp time.Time, facility, severity log_level.Priority, msgId string, sd StructuredData, msgFormat string, a ...interface{}) error {
+	if !l.enabled(severity) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.opts.beforeWrite(severity, msgId)
+		l.opts.afterWrite(severity, 0, ErrDropped)
+		return nil
+	}
+	msg := getBuffer()
+	defer putBuffer(msg)
+	fmt.Fprintf(msg, msgFormat, a...)
+	return l.log(timestamp, facility, severity, msgId, sd, msg.Bytes())
+}
+
+// log writes a message with an already formatted msg. A zero
+// timestamp is replaced by the time of the clock of the logger.
+func (l *WriterLogger) log(timestamp time.Time, facility, severity log_level.Priority, msgId string, sd StructuredData, msg []byte) error {
+	start := time.Now()
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	msg := fmt.Sprintf(msgFormat, a...)
-	l.w.Write(formatSyslog(
-		log_level.Priority(l.facility|severity),
-		time.Now(),
-		"",
-		l.hostname,
-		l.appName,
-		l.procid,
-		msgId,
-		sd,
-		[]byte(msg)))
+	if timestamp.IsZero() {
+		timestamp = l.opts.clock()
+	}
+	timestamp = l.opts.timestamp(timestamp)
+
+	if msgId == "" {
+		msgId = l.opts.msgID
+	}
+	err := l.optErr
+	if err == nil {
+		err = l.opts.checkFacilityAndSeverity(facility, severity)
+	}
+	if err != nil {
+		l.opts.beforeWrite(severity, msgId)
+		l.opts.afterWrite(severity, 0, err)
+		l.lastErr.store(err)
+		return err
+	}
+	severity, sd = l.opts.escalate(severity, msgId, msg, timestamp, sd)
+	severity = l.opts.clampSeverity(severity)
+	l.opts.beforeWrite(severity, msgId)
+	pri := facility | severity
+	sd = l.opts.structuredData(sd, pri, timestamp)
+	if template, ok := l.opts.templates[msgId]; ok {
+		msg = renderTemplate(template, sd)
+	}
+	if err := l.opts.validateSchema(sd); err != nil {
+		l.opts.afterWrite(severity, 0, err)
+		l.lastErr.store(err)
+		return err
+	}
+	if err := l.opts.allowApp(l.appName); err != nil {
+		l.opts.afterWrite(severity, 0, err)
+		return nil
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	var n int
+	for i, s := range l.sinks {
+		frame := l.opts.delimit(l.opts.format(buf, s.f, pri, timestamp, l.hostname, l.appName, l.procid, msgId, sd, msg))
+		written, werr := 0, l.opts.limitBytes(len(frame))
+		if werr == nil {
+			written, werr = s.w.Write(frame)
+			if werr == nil {
+				l.sizes.add(len(frame))
+				if l.capture != nil && i == 0 {
+					l.capture.Write(frame)
+				}
+			}
+		}
+		n += written
+		if err == nil {
+			err = werr
+		}
+	}
+	if err == nil {
+		l.opts.emitLatency.record(start)
+	}
+	l.opts.afterWrite(severity, n, err)
+	l.lastErr.store(err)
+	if err == ErrDropped {
+		return nil
+	}
+	return err
 }
 
 // StructuredData provides a mechanism to express information in a well
@@ -221,7 +513,8 @@ type StructuredData map[string]SDElement
 
 // Element returns an SDElement associated with the given id.
 // If an element with the id does not exist a new SDElement
-// will be created.
+// will be created. An element without params is never written and
+// is left out by Clone, use Delete to remove it from d.
 func (d StructuredData) Element(id string) SDElement {
 	elem, ok := d[id]
 	if !ok {
@@ -231,11 +524,62 @@ func (d StructuredData) Element(id string) SDElement {
 	return elem
 }
 
+// Delete removes the element with the given id.
+func (d StructuredData) Delete(id string) {
+	delete(d, id)
+}
+
+// Empty reports whether the structured data holds no element with at
+// least one param, in which case it is written as the NILVALUE.
+func (d StructuredData) Empty() bool {
+	for _, elem := range d {
+		if !elem.empty() {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a deep copy of the structured data: changes to the
+// elements of the copy don't affect d. Use it to derive the structured
+// data of a message from shared base data. Elements without params are
+// not copied.
+func (d StructuredData) Clone() StructuredData {
+	c := make(StructuredData, len(d))
+	for id, elem := range d {
+		if elem.empty() {
+			continue
+		}
+		e := make(SDElement, len(elem))
+		for name, value := range elem {
+			e[name] = value
+		}
+		c[id] = e
+	}
+	return c
+}
+
+// merge adds the params of src to dst, keeping the params that are
+// already present in dst.
+func merge(dst, src StructuredData) {
+	for id, elem := range src {
+		if elem.empty() {
+			continue
+		}
+		target := dst.Element(id)
+		for name, value := range elem {
+			if _, ok := target[name]; !ok {
+				target[name] = value
+			}
+		}
+	}
+}
+
 // Ids returns the ids of the SDElements in lexicographical order.
 func (d StructuredData) Ids() []string {
 	ids := make([]string, 0, len(d))
 	for id := range d {
-		if len(d[id]) > 0 {
+		if !d[id].empty() {
 			ids = append(ids, id)
 		}
 	}
@@ -245,21 +589,40 @@ func (d StructuredData) Ids() []string {
 
 // Strings returns the string representation of the structured data.
 func (d StructuredData) String() string {
-	r := strings.NewReplacer(`"`, `\"`, `\`, `\\`, `]`, `\]`)
 	buf := &bytes.Buffer{}
-	for _, id := range d.Ids() {
+	d.writeTo(buf, sdOrder{})
+	return buf.String()
+}
+
+// WriteTo writes the structured data to w as it is formatted in a
+// syslog message. It implements io.WriterTo.
+func (d StructuredData) WriteTo(w io.Writer) (int64, error) {
+	if buf, ok := w.(*bytes.Buffer); ok {
+		n := buf.Len()
+		d.writeTo(buf, sdOrder{})
+		return int64(buf.Len() - n), nil
+	}
+	buf := &bytes.Buffer{}
+	d.writeTo(buf, sdOrder{})
+	return buf.WriteTo(w)
+}
+
+// writeTo serializes the structured data directly into buf, without
+// building an intermediate string, in the given order.
+func (d StructuredData) writeTo(buf *bytes.Buffer, order sdOrder) {
+	for _, id := range order.ids(d) {
 		elem := d[id]
-		if len(elem) > 0 {
-			buf.WriteByte('[')
-			buf.WriteString(id)
-			for _, name := range elem.Names() {
-				buf.WriteByte(' ')
-				fmt.Fprintf(buf, `%s="%s"`, name, r.Replace(elem[name]))
-			}
-			buf.WriteByte(']')
+		buf.WriteByte('[')
+		buf.WriteString(id)
+		for _, name := range order.names(id, elem) {
+			buf.WriteByte(' ')
+			buf.WriteString(name)
+			buf.WriteString(`="`)
+			writeParamValue(buf, elem[name])
+			buf.WriteByte('"')
 		}
+		buf.WriteByte(']')
 	}
-	return buf.String()
 }
 
 // SDElement represents a structured data element and consists
@@ -272,6 +635,17 @@ func (e SDElement) Set(name, value string) SDElement {
 	return e
 }
 
+// SetInt sets the decimal representation of v, which GetInt parses.
+func (e SDElement) SetInt(name string, v int64) SDElement {
+	return e.Set(name, strconv.FormatInt(v, 10))
+}
+
+// SetTime sets t formatted as RFC 3339 with nanoseconds, for example
+// "2017-08-15T23:13:15.335Z".
+func (e SDElement) SetTime(name string, t time.Time) SDElement {
+	return e.Set(name, t.Format(time.RFC3339Nano))
+}
+
 // Get returns a value associated with the specified name.
 func (e SDElement) Get(name string) string {
 	value, ok := e[name]
@@ -281,6 +655,49 @@ func (e SDElement) Get(name string) string {
 	return value
 }
 
+// GetInt returns the value associated with the specified name
+// parsed as an int.
+func (e SDElement) GetInt(name string) (int, error) {
+	value, err := e.lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(value)
+}
+
+// GetBool returns the value associated with the specified name
+// parsed as a bool. It accepts the values accepted by strconv.ParseBool.
+func (e SDElement) GetBool(name string) (bool, error) {
+	value, err := e.lookup(name)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
+
+// GetFloat returns the value associated with the specified name
+// parsed as a float64.
+func (e SDElement) GetFloat(name string) (float64, error) {
+	value, err := e.lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// empty reports whether the element holds no params.
+func (e SDElement) empty() bool {
+	return len(e) == 0
+}
+
+func (e SDElement) lookup(name string) (string, error) {
+	value, ok := e[name]
+	if !ok {
+		return "", fmt.Errorf("syslog: param %q not found", name)
+	}
+	return value, nil
+}
+
 // Names returns the parameter names in lexicographical order.
 func (e SDElement) Names() []string {
 	names := make([]string, 0, len(e))
@@ -291,60 +708,60 @@ func (e SDElement) Names() []string {
 	return names
 }
 
-func Emergency(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) {
+func Emergency(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) error {
 	if l == nil {
-		return
+		return nil
 	}
-	l.Log(log_level.EMERGENCY, msgId, sd, format, a...)
+	return l.Log(log_level.EMERGENCY, msgId, sd, format, a...)
 }
 
-func Critical(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) {
+func Critical(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) error {
 	if l == nil {
-		return
+		return nil
 	}
-	l.Log(log_level.CRITICAL, msgId, sd, format, a...)
+	return l.Log(log_level.CRITICAL, msgId, sd, format, a...)
 }
 
-func Alert(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) {
+func Alert(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) error {
 	if l == nil {
-		return
+		return nil
 	}
-	l.Log(log_level.ALERT, msgId, sd, format, a...)
+	return l.Log(log_level.ALERT, msgId, sd, format, a...)
 }
 
-func Error(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) {
+func Error(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) error {
 	if l == nil {
-		return
+		return nil
 	}
-	l.Log(log_level.ERROR, msgId, sd, format, a...)
+	return l.Log(log_level.ERROR, msgId, sd, format, a...)
 }
 
-func Warning(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) {
+func Warning(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) error {
 	if l == nil {
-		return
+		return nil
 	}
-	l.Log(log_level.WARNING, msgId, sd, format, a...)
+	return l.Log(log_level.WARNING, msgId, sd, format, a...)
 }
 
-func Notice(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) {
+func Notice(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) error {
 	if l == nil {
-		return
+		return nil
 	}
-	l.Log(log_level.NOTICE, msgId, sd, format, a...)
+	return l.Log(log_level.NOTICE, msgId, sd, format, a...)
 }
 
-func Info(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) {
+func Info(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) error {
 	if l == nil {
-		return
+		return nil
 	}
-	l.Log(log_level.INFO, msgId, sd, format, a...)
+	return l.Log(log_level.INFO, msgId, sd, format, a...)
 }
 
-func Debug(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) {
+func Debug(l Logger, msgId string, sd StructuredData, format string, a ...interface{}) error {
 	if l == nil {
-		return
+		return nil
 	}
-	l.Log(log_level.DEBUG, msgId, sd, format, a...)
+	return l.Log(log_level.DEBUG, msgId, sd, format, a...)
 }
 
 func KeyBySeverity(severity log_level.Priority) string {
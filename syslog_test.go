@@ -8,10 +8,14 @@ import (
 	"bytes"
 	"github.com/confetti-framework/syslog"
 	"github.com/confetti-framework/syslog/log_level"
+	"io/ioutil"
 	"log"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func Test_writer(t *testing.T) {
@@ -80,3 +84,302 @@ func Test_structured_data(t *testing.T) {
 		t.Fatalf("got string: %v, but expected: %v", sd.String(), expectedString)
 	}
 }
+
+func Test_structured_data_typed_get(t *testing.T) {
+	elem := syslog.StructuredData{}.Element("id1").
+		Set("int", strconv.Itoa(42)).
+		Set("bool", strconv.FormatBool(true)).
+		Set("float", strconv.FormatFloat(1.5, 'f', -1, 64)).
+		Set("text", "abc")
+
+	i, err := elem.GetInt("int")
+	if err != nil || i != 42 {
+		t.Fatalf("got int: %v (%v), but expected: %v", i, err, 42)
+	}
+
+	b, err := elem.GetBool("bool")
+	if err != nil || b != true {
+		t.Fatalf("got bool: %v (%v), but expected: %v", b, err, true)
+	}
+
+	f, err := elem.GetFloat("float")
+	if err != nil || f != 1.5 {
+		t.Fatalf("got float: %v (%v), but expected: %v", f, err, 1.5)
+	}
+
+	if _, err := elem.GetInt("text"); err == nil {
+		t.Fatalf("expected error for non-numeric value")
+	}
+
+	if _, err := elem.GetBool("missing"); err == nil {
+		t.Fatalf("expected error for missing param")
+	}
+}
+
+func Test_compact_nil_values(t *testing.T) {
+	standard := &bytes.Buffer{}
+	syslog.NewWriter(standard, syslog.USER|log_level.NOTICE, "", "", "").Write([]byte("message"))
+
+	compact := &bytes.Buffer{}
+	syslog.NewWriter(compact, syslog.USER|log_level.NOTICE, "", "", "", syslog.WithCompactNilValues()).Write([]byte("message"))
+
+	if !strings.HasSuffix(standard.String(), " - - - - - message\n") {
+		t.Fatalf("non-expected standard output: %s", standard.String())
+	}
+	if strings.Contains(compact.String(), " - ") || !strings.HasSuffix(compact.String(), " message\n") {
+		t.Fatalf("non-expected compact output: %s", compact.String())
+	}
+	if len(strings.Fields(compact.String())) != 3 {
+		t.Fatalf("got compact output: %s, but expected only PRI, timestamp and message", compact.String())
+	}
+}
+
+func Test_compact_nil_values_keeps_inner_nil_values(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "", "appName", "", syslog.WithCompactNilValues())
+	l.Log(log_level.ERROR, "LoginFailed", nil, "login failed")
+
+	expectedSuffix := " - appName - LoginFailed login failed\n"
+	if !strings.HasSuffix(buf.String(), expectedSuffix) {
+		t.Fatalf("non-expected suffix: %s", buf.String())
+	}
+}
+
+func Test_clock(t *testing.T) {
+	fixed := time.Date(2021, 3, 4, 5, 6, 7, 8000000, time.UTC)
+	clock := syslog.WithClock(func() time.Time { return fixed })
+
+	buf := &bytes.Buffer{}
+	syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", clock).Write([]byte("message"))
+	syslog.NewLogger(buf, syslog.USER, "laptop", "testapp", "123", clock).Log(log_level.ERROR, "LoginFailed", nil, "login failed")
+
+	expected := "<13>1 2021-03-04T05:06:07.008+00:00 laptop testapp 123 - - message\n" +
+		"<11>1 2021-03-04T05:06:07.008+00:00 laptop testapp 123 LoginFailed - login failed\n"
+	if buf.String() != expected {
+		t.Fatalf("got: %q, but expected: %q", buf.String(), expected)
+	}
+}
+
+func Test_writer_msg_id(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := syslog.NewWriter(buf, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123", syslog.WithMsgID("TCPIN_APP"))
+	log.New(w, "", 0).Print("connection accepted")
+
+	if !strings.HasSuffix(buf.String(), " laptop testapp 123 TCPIN_APP - connection accepted\n") {
+		t.Fatalf("non-expected output: %s", buf.String())
+	}
+}
+
+func Test_logger_default_msg_id(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithMsgID("DEFAULT"))
+	l.Log(log_level.ERROR, "", nil, "first")
+	l.Log(log_level.ERROR, "LoginFailed", nil, "second")
+
+	lines := strings.Split(buf.String(), "\n")
+	if !strings.HasSuffix(lines[0], " procid DEFAULT - first") || !strings.HasSuffix(lines[1], " procid LoginFailed - second") {
+		t.Fatalf("non-expected output: %s", buf.String())
+	}
+}
+
+func Test_clone_structured_data(t *testing.T) {
+	base := syslog.StructuredData{}
+	base.Element("trace@32473").Set("id", "abc")
+	base.Element("service@32473").Set("name", "api")
+
+	c := base.Clone()
+	c.Element("trace@32473").Set("id", "def").Set("span", "1")
+	c.Element("service@32473").Set("version", "2")
+	c.Element("request@32473").Set("path", "/users")
+
+	expected := `[service@32473 name="api"][trace@32473 id="abc"]`
+	if base.String() != expected {
+		t.Fatalf("got original: %v, but expected: %v", base.String(), expected)
+	}
+	expected = `[request@32473 path="/users"][service@32473 name="api" version="2"][trace@32473 id="def" span="1"]`
+	if c.String() != expected {
+		t.Fatalf("got clone: %v, but expected: %v", c.String(), expected)
+	}
+}
+
+func Test_new_with_options(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.New(buf,
+		syslog.WithFacility(syslog.LOCAL0),
+		syslog.WithHostname("hostname"),
+		syslog.WithAppName("appName"),
+		syslog.WithProcID("procid"),
+		syslog.WithMinSeverity(log_level.NOTICE),
+		syslog.WithClock(func() time.Time { return time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC) }),
+	)
+	l.Log(log_level.INFO, "", nil, "dropped")
+	l.Log(log_level.ERROR, "LoginFailed", nil, "login failed")
+
+	expected := "<131>1 2021-03-04T05:06:07+00:00 hostname appName procid LoginFailed - login failed\n"
+	if buf.String() != expected {
+		t.Fatalf("got: %q, but expected: %q", buf.String(), expected)
+	}
+}
+
+func Test_new_defaults(t *testing.T) {
+	buf := &bytes.Buffer{}
+	syslog.New(buf).Log(log_level.NOTICE, "", nil, "message")
+
+	if !strings.HasPrefix(buf.String(), "<13>1 ") || !strings.HasSuffix(buf.String(), " - - - - - message\n") {
+		t.Fatalf("non-expected output: %q", buf.String())
+	}
+}
+
+func Test_structured_data_write_to(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", `va"l1`)
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("prefix ")
+	n, err := sd.WriteTo(buf)
+	if err != nil {
+		t.Fatalf("got error: %v, but expected: <nil>", err)
+	}
+
+	expected := `[id1 par1="va\"l1"]`
+	if buf.String() != "prefix "+expected {
+		t.Fatalf("got: %q, but expected: %q", buf.String(), "prefix "+expected)
+	}
+	if n != int64(len(expected)) {
+		t.Fatalf("got n: %v, but expected: %v", n, len(expected))
+	}
+}
+
+func Test_structured_data_write_to_writer(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+
+	out := &strings.Builder{}
+	n, err := sd.WriteTo(out)
+	if err != nil {
+		t.Fatalf("got error: %v, but expected: <nil>", err)
+	}
+	if out.String() != sd.String() || n != int64(out.Len()) {
+		t.Fatalf("got: %q (%d bytes), but expected: %q", out.String(), n, sd.String())
+	}
+}
+
+func Benchmark_logger_with_structured_data(b *testing.B) {
+	sd := syslog.StructuredData{}
+	sd.Element("request").Set("method", "GET").Set("path", "/users/42")
+	sd.Element("trace").Set("id", "abc")
+	l := syslog.NewLogger(ioutil.Discard, syslog.USER, "hostname", "appName", "procid")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Log(log_level.INFO, "msgid", sd, "message")
+	}
+}
+
+func Test_empty_element_is_not_written(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("unused")
+
+	if len(sd.Ids()) != 0 {
+		t.Fatalf("got ids: %v, but expected none", sd.Ids())
+	}
+	if sd.String() != "" {
+		t.Fatalf("got string: %q, but expected it to be empty", sd.String())
+	}
+	if !sd.Empty() {
+		t.Fatalf("got Empty: false, but expected: true")
+	}
+	if _, ok := sd.Clone()["unused"]; ok {
+		t.Fatalf("got the empty element in the clone, but expected it to be left out")
+	}
+
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid")
+	l.Log(log_level.INFO, "", sd, "message")
+	if !strings.HasSuffix(buf.String(), " hostname appName procid - - message\n") {
+		t.Fatalf("got: %q, but expected the NILVALUE as structured data", buf.String())
+	}
+}
+
+func Test_structured_data_delete(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+	sd.Element("id2").Set("par2", "val2")
+	sd.Delete("id1")
+	sd.Delete("missing")
+
+	expected := `[id2 par2="val2"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+	if sd.Empty() {
+		t.Fatalf("got Empty: true, but expected: false")
+	}
+
+	sd.Delete("id2")
+	if len(sd) != 0 || !sd.Empty() {
+		t.Fatalf("got structured data: %v, but expected it to be empty", sd)
+	}
+}
+
+func Test_log_at(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid")
+	ts := time.Date(2017, 8, 15, 23, 13, 15, 335e6, time.UTC)
+
+	l.LogAt(ts, log_level.INFO, "", nil, "replayed %d", 1)
+
+	expected := "<14>1 2017-08-15T23:13:15.335+00:00 hostname appName procid - - replayed 1\n"
+	if buf.String() != expected {
+		t.Fatalf("got: %q, but expected: %q", buf.String(), expected)
+	}
+}
+
+func Test_writer_formats_message_starting_with_angle_bracket(t *testing.T) {
+	for _, msg := range []string{"<nil> something happened", "<a/>", "<1234> too long", "<>", "<"} {
+		buf := &bytes.Buffer{}
+		w := syslog.NewWriter(buf, syslog.USER|log_level.INFO, "hostname", "appName", "procid")
+		w.Write([]byte(msg))
+
+		expected := regexp.MustCompile(`^<14>1 \S+ hostname appName procid - - ` + regexp.QuoteMeta(msg) + "\n$")
+		if !expected.MatchString(buf.String()) {
+			t.Fatalf("got: %q, but expected a formatted message", buf.String())
+		}
+	}
+}
+
+func Test_typed_setters(t *testing.T) {
+	sd := syslog.StructuredData{}
+	sd.Element("id1").
+		SetInt("count", -42).
+		SetTime("at", time.Date(2017, 8, 15, 23, 13, 15, 335e6, time.UTC))
+
+	expected := `[id1 at="2017-08-15T23:13:15.335Z" count="-42"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+	if n, err := sd.Element("id1").GetInt("count"); err != nil || n != -42 {
+		t.Fatalf("got int: %v (%v), but expected: %v", n, err, -42)
+	}
+}
+
+func Test_log_with_facility(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.DAEMON, "hostname", "appName", "procid")
+
+	l.LogWithFacility(syslog.AUTH, log_level.WARNING, "LoginFailed", nil, "login failed for %s", "alice")
+	l.Log(log_level.INFO, "", nil, "started")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	// AUTH is facility 4: 4*8+4 = 36, DAEMON facility 3: 3*8+6 = 30
+	expectedPrefixes := []string{"<36>1", "<30>1"}
+	for i, prefix := range expectedPrefixes {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Fatalf("got: %s, but expected prefix: %s", lines[i], prefix)
+		}
+	}
+	if !strings.HasSuffix(lines[0], " LoginFailed - login failed for alice") {
+		t.Fatalf("non-expected message: %s", lines[0])
+	}
+}
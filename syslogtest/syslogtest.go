@@ -0,0 +1,69 @@
+// Package syslogtest provides helpers to test code that logs with the
+// syslog package by the fields of the messages instead of their text.
+package syslogtest
+
+import (
+	"github.com/confetti-framework/syslog"
+	"sync"
+)
+
+// Capture is an io.Writer that records every message written to it,
+// decoded by syslog.Parse. Use it as the destination of a Writer or
+// Logger:
+//
+//	c := syslogtest.NewCapture()
+//	l := syslog.NewLogger(c, syslog.USER, "hostname", "appName", "procid")
+//	l.Log(log_level.ERROR, "LoginFailed", sd, "login failed")
+//	if msg := c.LastMessage(); msg.Severity() != log_level.ERROR { ... }
+//
+// Every write must hold a single message, as written by writers
+// without a write buffer. Capture is safe for concurrent use by
+// multiple goroutines.
+type Capture struct {
+	mu       sync.Mutex
+	messages []*syslog.Message
+}
+
+// NewCapture returns an empty Capture.
+func NewCapture() *Capture {
+	return &Capture{}
+}
+
+// Write decodes and records the message p. It returns the error of
+// syslog.Parse for a write that doesn't hold a valid syslog message.
+func (c *Capture) Write(p []byte) (int, error) {
+	m, err := syslog.Parse(p)
+	if err != nil {
+		return 0, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append(c.messages, m)
+	return len(p), nil
+}
+
+// Messages returns the recorded messages in the order in which they
+// were written.
+func (c *Capture) Messages() []*syslog.Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*syslog.Message(nil), c.messages...)
+}
+
+// LastMessage returns the last recorded message, or nil if no message
+// was written.
+func (c *Capture) LastMessage() *syslog.Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.messages) == 0 {
+		return nil
+	}
+	return c.messages[len(c.messages)-1]
+}
+
+// Reset removes the recorded messages.
+func (c *Capture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = nil
+}
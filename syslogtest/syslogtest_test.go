@@ -0,0 +1,54 @@
+package syslogtest_test
+
+import (
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/confetti-framework/syslog/syslogtest"
+	"testing"
+)
+
+func Test_capture(t *testing.T) {
+	c := syslogtest.NewCapture()
+	l := syslog.NewLogger(c, syslog.LOCAL0, "hostname", "appName", "procid")
+
+	if c.LastMessage() != nil {
+		t.Fatalf("got message: %v, but expected: <nil>", c.LastMessage())
+	}
+
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+	l.Log(log_level.WARNING, "", nil, "first")
+	l.Log(log_level.ERROR, "LoginFailed", sd, "login failed")
+
+	if len(c.Messages()) != 2 {
+		t.Fatalf("got messages: %v, but expected: %v", len(c.Messages()), 2)
+	}
+	msg := c.LastMessage()
+	if msg.Severity() != log_level.ERROR {
+		t.Fatalf("got severity: %v, but expected: %v", msg.Severity(), log_level.ERROR)
+	}
+	if msg.Facility() != syslog.LOCAL0 {
+		t.Fatalf("got facility: %v, but expected: %v", msg.Facility(), syslog.LOCAL0)
+	}
+	if msg.AppName != "appName" || msg.MsgID != "LoginFailed" {
+		t.Fatalf("got app name: %v and msgid: %v, but expected: appName and LoginFailed", msg.AppName, msg.MsgID)
+	}
+	if got := msg.StructuredData.Element("id1").Get("par1"); got != "val1" {
+		t.Fatalf("got param: %v, but expected: %v", got, "val1")
+	}
+	if string(msg.Msg) != "login failed" {
+		t.Fatalf("got msg: %q, but expected: %q", msg.Msg, "login failed")
+	}
+
+	c.Reset()
+	if len(c.Messages()) != 0 {
+		t.Fatalf("got messages: %v, but expected none", c.Messages())
+	}
+}
+
+func Test_capture_invalid_message(t *testing.T) {
+	c := syslogtest.NewCapture()
+	if _, err := c.Write([]byte("not syslog")); err == nil {
+		t.Fatalf("got error: <nil>, but expected a parse error")
+	}
+}
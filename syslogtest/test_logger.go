@@ -0,0 +1,25 @@
+package syslogtest
+
+import (
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+// NewTestLogger returns a Logger that writes every message with
+// tb.Log, so the messages logged during a test are associated with
+// the test and only shown when it fails or runs verbosely.
+func NewTestLogger(tb testing.TB, facility log_level.Priority, hostname, appName, procid string, opts ...syslog.Option) *syslog.WriterLogger {
+	return syslog.NewLogger(tbWriter{tb}, facility, hostname, appName, procid, opts...)
+}
+
+type tbWriter struct {
+	tb testing.TB
+}
+
+func (w tbWriter) Write(p []byte) (int, error) {
+	w.tb.Helper()
+	w.tb.Log(strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
@@ -0,0 +1,39 @@
+package syslogtest_test
+
+import (
+	"fmt"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/confetti-framework/syslog/syslogtest"
+	"strings"
+	"testing"
+)
+
+// fakeTB records the arguments of Log.
+type fakeTB struct {
+	testing.TB
+	logs []string
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Log(args ...interface{}) {
+	tb.logs = append(tb.logs, fmt.Sprint(args...))
+}
+
+func Test_test_logger(t *testing.T) {
+	tb := &fakeTB{}
+	l := syslogtest.NewTestLogger(tb, syslog.USER, "hostname", "appName", "procid")
+	l.Log(log_level.ERROR, "LoginFailed", nil, "login failed")
+	l.Log(log_level.INFO, "", nil, "logged in")
+
+	if len(tb.logs) != 2 {
+		t.Fatalf("got logs: %q, but expected 2", tb.logs)
+	}
+	if !strings.HasPrefix(tb.logs[0], "<11>1 ") || !strings.HasSuffix(tb.logs[0], " hostname appName procid LoginFailed - login failed") {
+		t.Fatalf("non-expected log: %q", tb.logs[0])
+	}
+	if !strings.HasSuffix(tb.logs[1], " - - logged in") {
+		t.Fatalf("non-expected log: %q", tb.logs[1])
+	}
+}
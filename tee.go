@@ -0,0 +1,27 @@
+package syslog
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"io"
+)
+
+// NewTeeFormatterLogger returns a Logger that writes every message to
+// two destinations, each in its own format: formatted by primary to
+// primaryW and by secondary to secondaryW. Both formats are built from
+// the same message, so they share the timestamp and structured data.
+// The Formatter set by WithFormatter is ignored.
+// The returned Logger is safe for concurrent use by
+// multiple goroutines.
+func NewTeeFormatterLogger(
+	primary Formatter,
+	primaryW io.Writer,
+	secondary Formatter,
+	secondaryW io.Writer,
+	facility log_level.Priority,
+	hostname, appName, procid string,
+	opts ...Option,
+) *WriterLogger {
+	l := NewLogger(primaryW, facility, hostname, appName, procid, opts...)
+	l.sinks = []sink{{primaryW, primary}, {secondaryW, secondary}}
+	return l
+}
@@ -0,0 +1,29 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"regexp"
+	"testing"
+)
+
+func Test_tee_formatter_logger(t *testing.T) {
+	collector := &bytes.Buffer{}
+	local := &bytes.Buffer{}
+	l := syslog.NewTeeFormatterLogger(
+		syslog.NewMinimalFormatter(), collector,
+		syslog.NewRFC3164Formatter(), local,
+		syslog.USER, "hostname", "appName", "procid")
+
+	sd := syslog.StructuredData{}
+	sd.Element("id1").Set("par1", "val1")
+	l.Log(log_level.ERROR, "LoginFailed", sd, "login failed")
+
+	if !regexp.MustCompile(`^<11>1 \S+ - - - - - login failed\n$`).MatchString(collector.String()) {
+		t.Fatalf("non-expected primary output: %q", collector.String())
+	}
+	if !regexp.MustCompile(`^<11>\w{3} [ \d]\d \S+ hostname appName\[procid\]: login failed \[id1 par1="val1"\]\n$`).MatchString(local.String()) {
+		t.Fatalf("non-expected secondary output: %q", local.String())
+	}
+}
@@ -0,0 +1,52 @@
+package syslog
+
+import "strings"
+
+// WithMessageTemplates sets the text of messages by their msgid, to
+// keep the text of messages consistent. A template such as
+// "login failed for {user}" is filled with the values of the params
+// of the structured data of the message; placeholders without a param
+// are left as is. Messages with a msgid without template keep the text
+// given to Log.
+func WithMessageTemplates(templates map[string]string) Option {
+	return func(o *options) {
+		o.templates = templates
+	}
+}
+
+// renderTemplate replaces the {name} placeholders in template by the
+// value of the param name. When multiple elements have the param, the
+// element with the lowest id wins.
+func renderTemplate(template string, sd StructuredData) []byte {
+	buf := &strings.Builder{}
+	for {
+		open := strings.IndexByte(template, '{')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(template[open:], '}')
+		if end < 0 {
+			break
+		}
+		end += open
+
+		buf.WriteString(template[:open])
+		if value, ok := lookupParam(sd, template[open+1:end]); ok {
+			buf.WriteString(value)
+		} else {
+			buf.WriteString(template[open : end+1])
+		}
+		template = template[end+1:]
+	}
+	buf.WriteString(template)
+	return []byte(buf.String())
+}
+
+func lookupParam(sd StructuredData, name string) (string, bool) {
+	for _, id := range sd.Ids() {
+		if value, ok := sd[id][name]; ok {
+			return value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,30 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+)
+
+func Test_message_templates(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", syslog.WithMessageTemplates(map[string]string{
+		"LoginFailed": "login failed for {user} from {ip}: {reason}",
+	}))
+
+	sd := syslog.StructuredData{}
+	sd.Element("auth").Set("user", "bob")
+	sd.Element("client").Set("ip", "10.0.0.1")
+	l.Log(log_level.ERROR, "LoginFailed", sd, "ignored")
+	l.Log(log_level.INFO, "ImageUploaded", nil, "image uploaded by %s", "bob")
+
+	lines := strings.Split(buf.String(), "\n")
+	if !strings.HasSuffix(lines[0], "] login failed for bob from 10.0.0.1: {reason}") {
+		t.Fatalf("non-expected message: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], " ImageUploaded - image uploaded by bob") {
+		t.Fatalf("non-expected message: %s", lines[1])
+	}
+}
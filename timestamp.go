@@ -0,0 +1,51 @@
+package syslog
+
+import (
+	"strings"
+	"time"
+)
+
+// WithTimestampPrecision sets the number of digits of the fractional
+// seconds of the timestamps written by the default RFC 5424 formatting,
+// from 0 for whole seconds to 6 for microseconds. Digits outside this
+// range are clamped. Like the default precision of milliseconds,
+// trailing zeros of the fraction are omitted.
+func WithTimestampPrecision(digits int) Option {
+	return func(o *options) {
+		o.timeFormat = timestampLayout(digits)
+	}
+}
+
+// WithUTC converts the timestamps of the messages to UTC before they
+// are formatted, so they are written with the offset +00:00 whatever
+// the time zone of the host. By default timestamps are written in the
+// local time of the host.
+func WithUTC() Option {
+	return func(o *options) {
+		o.utc = true
+	}
+}
+
+// timestamp returns the timestamp of a message that is created at t.
+func (o *options) timestamp(t time.Time) time.Time {
+	t = o.monotonic.timestamp(t)
+	if o.utc {
+		t = t.UTC()
+	}
+	return t
+}
+
+// timestampLayout returns the RFC 3339 layout with the given number of
+// digits of fractional seconds.
+func timestampLayout(digits int) string {
+	if digits < 0 {
+		digits = 0
+	}
+	if digits > 6 {
+		digits = 6
+	}
+	if digits == 0 {
+		return "2006-01-02T15:04:05-07:00"
+	}
+	return "2006-01-02T15:04:05." + strings.Repeat("9", digits) + "-07:00"
+}
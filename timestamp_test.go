@@ -0,0 +1,60 @@
+package syslog_test
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_timestamp_precision(t *testing.T) {
+	ts := time.Date(2017, 8, 15, 23, 13, 15, 123456789, time.UTC)
+	tests := []struct {
+		opts     []syslog.Option
+		expected string
+	}{
+		{nil, "2017-08-15T23:13:15.123+00:00"},
+		{[]syslog.Option{syslog.WithTimestampPrecision(0)}, "2017-08-15T23:13:15+00:00"},
+		{[]syslog.Option{syslog.WithTimestampPrecision(3)}, "2017-08-15T23:13:15.123+00:00"},
+		{[]syslog.Option{syslog.WithTimestampPrecision(6)}, "2017-08-15T23:13:15.123456+00:00"},
+		{[]syslog.Option{syslog.WithTimestampPrecision(9)}, "2017-08-15T23:13:15.123456+00:00"},
+	}
+
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		opts := append([]syslog.Option{syslog.WithClock(func() time.Time { return ts })}, test.opts...)
+		l := syslog.NewLogger(buf, syslog.USER, "hostname", "appName", "procid", opts...)
+		l.Log(log_level.INFO, "", nil, "message")
+
+		expected := "<14>1 " + test.expected + " hostname"
+		if !strings.HasPrefix(buf.String(), expected) {
+			t.Fatalf("got: %q, but expected prefix: %q", buf.String(), expected)
+		}
+	}
+}
+
+func Test_utc(t *testing.T) {
+	ts := time.Date(2017, 8, 15, 23, 13, 15, 123456789, time.FixedZone("CEST", 2*60*60))
+	tests := []struct {
+		opts     []syslog.Option
+		expected string
+	}{
+		{nil, "2017-08-15T23:13:15.123+02:00"},
+		{[]syslog.Option{syslog.WithUTC()}, "2017-08-15T21:13:15.123+00:00"},
+		{[]syslog.Option{syslog.WithUTC(), syslog.WithTimestampPrecision(6)}, "2017-08-15T21:13:15.123456+00:00"},
+	}
+
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		opts := append([]syslog.Option{syslog.WithClock(func() time.Time { return ts })}, test.opts...)
+		w := syslog.NewWriter(buf, syslog.USER|log_level.INFO, "hostname", "appName", "procid", opts...)
+		w.Write([]byte("message"))
+
+		expected := "<14>1 " + test.expected + " hostname"
+		if !strings.HasPrefix(buf.String(), expected) {
+			t.Fatalf("got: %q, but expected prefix: %q", buf.String(), expected)
+		}
+	}
+}
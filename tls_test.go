@@ -0,0 +1,75 @@
+package syslog_test
+
+import (
+	"bufio"
+	"crypto/tls"
+	"github.com/confetti-framework/syslog"
+	"github.com/confetti-framework/syslog/log_level"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// tlsConfigs returns the configuration of a TLS server with a self
+// signed certificate and of a client that trusts it.
+func tlsConfigs(t *testing.T) (server, client *tls.Config) {
+	s := httptest.NewTLSServer(http.NotFoundHandler())
+	defer s.Close()
+	server = &tls.Config{Certificates: s.TLS.Certificates}
+	client = s.Client().Transport.(*http.Transport).TLSClientConfig
+	return server, client
+}
+
+func Test_dial_tls(t *testing.T) {
+	serverConfig, clientConfig := tlsConfigs(t)
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	received := make(chan string)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		received <- readOctetCounted(t, bufio.NewReader(conn))
+	}()
+
+	w, err := syslog.DialTLS(ln.Addr().String(), clientConfig, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	w.Write([]byte("message\n"))
+
+	if msg := <-received; !strings.HasPrefix(msg, "<13>1 ") || !strings.HasSuffix(msg, " laptop testapp 123 - - message") {
+		t.Fatalf("non-expected message: %q", msg)
+	}
+}
+
+func Test_dial_tls_handshake_failure(t *testing.T) {
+	serverConfig, _ := tlsConfigs(t)
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.(*tls.Conn).Handshake()
+	}()
+
+	// the self signed certificate of the server is not trusted
+	_, err = syslog.DialTLS(ln.Addr().String(), &tls.Config{}, syslog.USER|log_level.NOTICE, "laptop", "testapp", "123")
+	if err == nil || !strings.Contains(err.Error(), "TLS connection") {
+		t.Fatalf("got error: %v, but expected a handshake error", err)
+	}
+}
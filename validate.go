@@ -0,0 +1,109 @@
+package syslog
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSDNameLength is the maximum length of an SD-ID or PARAM-NAME.
+const maxSDNameLength = 32
+
+// registeredSDIDs are the SD-IDs registered with IANA, which are the
+// only SD-IDs allowed without an enterprise number.
+var registeredSDIDs = map[string]bool{
+	"timeQuality": true,
+	"origin":      true,
+	"meta":        true,
+}
+
+// Validate reports whether the structured data can be written as
+// defined in RFC 5424. SD-IDs and param names must consist of 1 to 32
+// printable US-ASCII characters other than '=', space, ']' and '"'.
+// SD-IDs other than the ones registered with IANA (timeQuality,
+// origin and meta) must have the form name@enterprise, where
+// enterprise is a private enterprise number such as 32473.
+//
+// String does not validate the structured data, it writes illegal
+// SD-IDs and param names as they are.
+func (d StructuredData) Validate() error {
+	for _, id := range d.Ids() {
+		if err := validateSDID(id); err != nil {
+			return err
+		}
+		for _, name := range d[id].Names() {
+			if err := validateSDName(name); err != nil {
+				return fmt.Errorf("syslog: param of SD-ID %q: %w", id, err)
+			}
+		}
+	}
+	return nil
+}
+
+// SetValid sets a value like Set, but returns an error and leaves the
+// element unchanged if the name is not a valid param name or the value
+// would not be written as it is. Values may not contain '"', '\' and
+// ']', which are escaped, and control characters and invalid UTF-8,
+// which are replaced.
+func (e SDElement) SetValid(name, value string) (SDElement, error) {
+	if err := validateSDName(name); err != nil {
+		return e, fmt.Errorf("syslog: param name: %w", err)
+	}
+	if !utf8.ValidString(value) {
+		return e, fmt.Errorf("syslog: value of param %q is not valid UTF-8", name)
+	}
+	for _, r := range value {
+		if r == '"' || r == '\\' || r == ']' || r < ' ' || r == 0x7f {
+			return e, fmt.Errorf("syslog: value of param %q contains invalid character %q", name, r)
+		}
+	}
+	return e.Set(name, value), nil
+}
+
+func validateSDID(id string) error {
+	if err := validateSDName(id); err != nil {
+		return fmt.Errorf("syslog: SD-ID: %w", err)
+	}
+	at := strings.IndexByte(id, '@')
+	if at < 0 {
+		if !registeredSDIDs[id] {
+			return fmt.Errorf("syslog: SD-ID %q is not registered and has no enterprise number", id)
+		}
+		return nil
+	}
+	if at == 0 || !isEnterpriseNumber(id[at+1:]) {
+		return fmt.Errorf("syslog: SD-ID %q does not have the form name@enterprise", id)
+	}
+	return nil
+}
+
+// validateSDName validates an SD-NAME, the grammar shared by SD-IDs and
+// param names.
+func validateSDName(name string) error {
+	if name == "" || len(name) > maxSDNameLength {
+		return fmt.Errorf("%q must have 1 to %d characters", name, maxSDNameLength)
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !isPrintUSASCII(c) || c == '=' || c == ']' || c == '"' {
+			return fmt.Errorf("%q contains invalid character %q", name, c)
+		}
+	}
+	return nil
+}
+
+// isEnterpriseNumber reports whether s is a private enterprise number,
+// optionally followed by dot separated sub identifiers.
+func isEnterpriseNumber(s string) bool {
+	for _, part := range strings.Split(s, ".") {
+		if part == "" {
+			return false
+		}
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package syslog_test
+
+import (
+	"github.com/confetti-framework/syslog"
+	"testing"
+)
+
+func Test_validate_structured_data(t *testing.T) {
+	for _, id := range []string{"foo@32473", "exampleSDID@32473.1.2", "origin", "timeQuality", "meta"} {
+		sd := syslog.StructuredData{}
+		sd.Element(id).Set("par1", "val1")
+		if err := sd.Validate(); err != nil {
+			t.Fatalf("expected SD-ID %q to be valid, got: %v", id, err)
+		}
+	}
+
+	for _, id := range []string{"foo bar", "foo", "foo@", "@32473", "foo@bar", `foo"@32473`, "foo]@32473", "foo=@32473"} {
+		sd := syslog.StructuredData{}
+		sd.Element(id).Set("par1", "val1")
+		if err := sd.Validate(); err == nil {
+			t.Fatalf("expected an error for SD-ID %q", id)
+		}
+	}
+
+	for _, name := range []string{"par 1", "par=1", "", "a234567890123456789012345678901234"} {
+		sd := syslog.StructuredData{}
+		sd.Element("foo@32473").Set(name, "val1")
+		if err := sd.Validate(); err == nil {
+			t.Fatalf("expected an error for param name %q", name)
+		}
+	}
+}
+
+func Test_set_valid(t *testing.T) {
+	sd := syslog.StructuredData{}
+	if _, err := sd.Element("id1").SetValid("par1", "val1"); err != nil {
+		t.Fatalf("got error: %v, but expected: <nil>", err)
+	}
+
+	tests := []struct {
+		name, value, expected string
+	}{
+		{"par=2", "val2", `syslog: param name: "par=2" contains invalid character '='`},
+		{"", "val2", `syslog: param name: "" must have 1 to 32 characters`},
+		{"par2", "a]b", `syslog: value of param "par2" contains invalid character ']'`},
+		{"par2", "line\nbreak", `syslog: value of param "par2" contains invalid character '\n'`},
+		{"par2", "\xff", `syslog: value of param "par2" is not valid UTF-8`},
+	}
+	for _, test := range tests {
+		_, err := sd.Element("id1").SetValid(test.name, test.value)
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("got error: %v, but expected: %v", err, test.expected)
+		}
+	}
+
+	expected := `[id1 par1="val1"]`
+	if sd.String() != expected {
+		t.Fatalf("got string: %v, but expected: %v", sd.String(), expected)
+	}
+}